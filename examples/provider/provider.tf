@@ -2,4 +2,12 @@ provider "ravel" {
   url = "https://domino.ai/ravel"
 
   token = "ABC-123"
+
+  default_scope = {
+    category = "fleetcommand-configuration-manager"
+  }
+
+  default_labels = {
+    owner = "platform"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,8 @@
+# Import the latest version of a configuration by id
+terraform import ravel_configuration.example 7eb918e0-49b6-4519-bb5a-850c42d8da04
+
+# Import a specific version of a configuration
+terraform import ravel_configuration.example 7eb918e0-49b6-4519-bb5a-850c42d8da04@3
+
+# Look up the configuration by name and scope
+terraform import ravel_configuration.example 'name=smtp;scope.type=configuration;scope.fleetcommand_account=acme'
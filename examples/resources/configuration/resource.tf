@@ -23,6 +23,10 @@ resource "ravel_configuration" "example" {
       }
     }
   })
+
+  sensitive_paths = [
+    "email_notifications.authentication.password",
+  ]
 }
 
 output "id" {
@@ -32,3 +36,66 @@ output "id" {
 output "version" {
   value = ravel_configuration.example.version
 }
+
+output "definition_hash" {
+  value = ravel_configuration.example.definition_hash
+}
+
+resource "ravel_configuration" "from_yaml" {
+  name = "test-yaml"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  definition_yaml = <<-YAML
+    # Notifications sent by Domino
+    email_notifications:
+      enabled: true
+      server: smtp.customer.org
+      port: 465
+  YAML
+}
+
+resource "ravel_configuration" "from_settings" {
+  name = "test-settings"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  settings = {
+    email_notifications = {
+      enabled      = true
+      server       = "smtp.customer.org"
+      port         = 465
+      from_address = "domino@customer.org"
+    }
+  }
+}
+
+resource "ravel_configuration" "from_file" {
+  name = "test-file"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  definition_file = "${path.module}/smtp.yaml"
+}
+
+resource "ravel_configuration" "layered" {
+  name = "test-layered"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  base_configuration_id = ravel_configuration.example.id
+
+  overrides = jsonencode({
+    "email_notifications" : {
+      "from_address" : "account@customer.org"
+    }
+  })
+}
@@ -0,0 +1,2 @@
+# Import the given label keys of a configuration
+terraform import ravel_configuration_labels.release 7eb918e0-49b6-4519-bb5a-850c42d8da04:minDominoVersion,minSchemaVersion
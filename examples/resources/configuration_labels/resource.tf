@@ -0,0 +1,24 @@
+resource "ravel_configuration" "smtp" {
+  name = "smtp"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "server" : "smtp.customer.org",
+      "port" : 465
+    }
+  })
+}
+
+# Owned by the release team, other label keys are left untouched
+resource "ravel_configuration_labels" "release" {
+  configuration_id = ravel_configuration.smtp.id
+
+  labels = {
+    minDominoVersion = "005.009.000"
+    minSchemaVersion = "1.0.0"
+  }
+}
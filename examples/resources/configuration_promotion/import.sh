@@ -0,0 +1,2 @@
+# Import the promotion recorded on a target configuration by its id
+terraform import 'ravel_configuration_promotion.production["acme"]' 7eb918e0-49b6-4519-bb5a-850c42d8da04
@@ -0,0 +1,30 @@
+resource "ravel_configuration" "staging" {
+  name = "smtp"
+
+  scope = {
+    fleetcommand_account = "staging"
+  }
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "server" : "smtp.customer.org",
+      "port" : 465
+    }
+  })
+}
+
+# Copy the staged version to every production account
+resource "ravel_configuration_promotion" "production" {
+  for_each = toset(["acme", "globex"])
+
+  source_configuration_id = ravel_configuration.staging.id
+  source_version          = ravel_configuration.staging.version
+
+  target_scope = {
+    fleetcommand_account = each.key
+  }
+}
+
+output "promoted_from" {
+  value = { for account, promotion in ravel_configuration_promotion.production : account => promotion.promoted_from }
+}
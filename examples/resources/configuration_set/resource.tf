@@ -0,0 +1,34 @@
+variable "accounts" {
+  type = map(object({
+    smtp_server = string
+  }))
+}
+
+# One batched reconciliation for the whole fleet instead of one resource per account
+resource "ravel_configuration_set" "smtp" {
+  configurations = {
+    for account, settings in var.accounts : account => {
+      name = "smtp"
+
+      scope = {
+        fleetcommand_account = account
+      }
+
+      labels = {
+        minDominoVersion = "005.008.000"
+      }
+
+      definition = jsonencode({
+        "email_notifications" : {
+          "enabled" : true,
+          "server" : settings.smtp_server,
+          "port" : 465
+        }
+      })
+    }
+  }
+}
+
+output "versions" {
+  value = { for account, configuration in ravel_configuration_set.smtp.configurations : account => configuration.version }
+}
@@ -0,0 +1,2 @@
+# Import version 3 of a configuration
+terraform import ravel_configuration_version.smtp 7eb918e0-49b6-4519-bb5a-850c42d8da04@3
@@ -0,0 +1,41 @@
+# The configuration identity is owned by another module, which only publishes its initial definition
+resource "ravel_configuration" "smtp" {
+  name = "smtp"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "enabled" : false
+    }
+  })
+
+  track_latest = true
+
+  lifecycle {
+    ignore_changes = [definition]
+  }
+}
+
+# Every change publishes a new version, destroying the resource keeps the version in the history
+resource "ravel_configuration_version" "smtp" {
+  configuration_id = ravel_configuration.smtp.id
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "enabled" : true,
+      "server" : "smtp.customer.org",
+      "port" : 465
+    }
+  })
+}
+
+output "version" {
+  value = ravel_configuration_version.smtp.version
+}
+
+output "created_at" {
+  value = ravel_configuration_version.smtp.created_at
+}
@@ -0,0 +1,2 @@
+# Import a schema by id
+terraform import ravel_schema.smtp 0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21
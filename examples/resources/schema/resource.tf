@@ -0,0 +1,58 @@
+resource "ravel_schema" "smtp" {
+  name    = "smtp"
+  version = "1.0.0"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  labels = {
+    owner = "platform"
+  }
+
+  definition = jsonencode({
+    "$schema" : "https://json-schema.org/draft/2020-12/schema",
+    "type" : "object",
+    "required" : ["email_notifications"],
+    "properties" : {
+      "email_notifications" : {
+        "type" : "object",
+        "required" : ["server", "port"],
+        "properties" : {
+          "server" : { "type" : "string" },
+          "port" : { "type" : "integer" }
+        }
+      }
+    }
+  })
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Configurations validated by the schema ship in the same plan
+resource "ravel_configuration" "smtp" {
+  name = "smtp"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  schema = {
+    name    = ravel_schema.smtp.name
+    version = ravel_schema.smtp.version
+    scope   = ravel_schema.smtp.scope
+  }
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "server" : "smtp.customer.org",
+      "port" : 465
+    }
+  })
+}
+
+output "schema_id" {
+  value = ravel_schema.smtp.id
+}
@@ -0,0 +1,2 @@
+# Import a secret by reference, the next apply rotates it to the configured value
+terraform import ravel_secret.smtp_password secret://smtp/0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21
@@ -0,0 +1,37 @@
+variable "smtp_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "ravel_secret" "smtp_password" {
+  store = "smtp"
+  value = var.smtp_password
+
+  labels = {
+    owner = "platform"
+  }
+}
+
+# The definition only holds the reference, the plaintext never appears in the configuration
+resource "ravel_configuration" "smtp" {
+  name = "smtp"
+
+  scope = {
+    terraform = "testing"
+  }
+
+  definition = jsonencode({
+    "email_notifications" : {
+      "server" : "smtp.customer.org",
+      "port" : 465,
+      "authentication" : {
+        "username" : "domino",
+        "password" : ravel_secret.smtp_password.reference
+      }
+    }
+  })
+}
+
+output "reference" {
+  value = ravel_secret.smtp_password.reference
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ConfigBatchSize is the maximum number of configurations sent in a single request, larger batches are split.
+const ConfigBatchSize = 100
+
+const (
+	ConfigOpCreate = "create"
+	ConfigOpUpdate = "update"
+	ConfigOpDelete = "delete"
+)
+
+// ConfigOperationResult is the outcome of a configuration batch operation. Err is set when the operation failed,
+// it is a *RavelError, or a *VersionConflictError for updates rejected because of a concurrent version.
+type ConfigOperationResult struct {
+	Config *models.RavelConfig
+	Err    error
+}
+
+// BatchConfigs applies the operations and returns their results in the same order. Operations are independent,
+// the failure of one of them does not prevent the others from being applied. When a batch cannot be sent the
+// results of the batches already applied are returned with the error.
+func (rc *RavelClient) BatchConfigs(c context.Context, ops []models.RavelConfigOperation) ([]ConfigOperationResult, error) {
+	results := make([]ConfigOperationResult, 0, len(ops))
+
+	for start := 0; start < len(ops); start += ConfigBatchSize {
+		chunk := ops[start:min(start+ConfigBatchSize, len(ops))]
+
+		tflog.Info(c, fmt.Sprintf("Batch configs: %d operations", len(chunk)))
+		res, err := rc.httpClient.R().SetContext(c).SetBody(map[string]any{
+			"operations": chunk,
+		}).Post("/configurations/batch")
+		if err := rc.handleError(res, err); err != nil {
+			return results, err
+		}
+
+		var body struct {
+			Results []models.RavelConfigOperationResult `json:"results"`
+		}
+		if err := json.Unmarshal(res.Body(), &body); err != nil {
+			return results, err
+		}
+
+		if len(body.Results) != len(chunk) {
+			return results, fmt.Errorf("batch of %d configuration operations returned %d results", len(chunk), len(body.Results))
+		}
+
+		for i, result := range body.Results {
+			results = append(results, operationResult(chunk[i], result, res.Request.URL, res.Header().Get(requestIdHeader)))
+		}
+	}
+
+	return results, nil
+}
+
+func operationResult(op models.RavelConfigOperation, result models.RavelConfigOperationResult, requestURL string, requestId string) ConfigOperationResult {
+	if result.Status < 300 {
+		return ConfigOperationResult{Config: result.Config}
+	}
+
+	var err error = &RavelError{
+		URL:        requestURL,
+		StatusCode: result.Status,
+		Code:       result.Code,
+		Message:    result.Message,
+		RequestId:  requestId,
+	}
+
+	if op.Op == ConfigOpUpdate && IsConflict(err) {
+		var expectedVersion int64
+		if op.ExpectedVersion != nil {
+			expectedVersion = *op.ExpectedVersion
+		}
+		err = &VersionConflictError{ConfigId: op.Id, ExpectedVersion: expectedVersion, Err: err}
+	}
+
+	return ConfigOperationResult{Err: err}
+}
+
+// GetLatestConfigs returns the latest version of the configurations with the given ids, configurations that do
+// not exist are left out.
+func (rc *RavelClient) GetLatestConfigs(c context.Context, configIds []string) ([]models.RavelConfig, error) {
+	configs := make([]models.RavelConfig, 0, len(configIds))
+
+	for start := 0; start < len(configIds); start += ConfigBatchSize {
+		chunk := configIds[start:min(start+ConfigBatchSize, len(configIds))]
+
+		res, err := rc.httpClient.R().SetContext(c).SetQueryParamsFromValues(url.Values{
+			"id": chunk,
+		}).Get("/configurations")
+		if err := rc.handleError(res, err); err != nil {
+			return nil, err
+		}
+
+		var chunkConfigs []models.RavelConfig
+		if err := json.Unmarshal(res.Body(), &chunkConfigs); err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, chunkConfigs...)
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const requestIdHeader = "X-Request-Id"
+
+// RavelError is returned by RavelClient whenever the Ravel API answers with a non-2xx status.
+type RavelError struct {
+	URL        string
+	StatusCode int
+	Code       string
+	Message    string
+	RequestId  string
+	Retryable  bool
+	// Body holds the decoded JSON body returned by the server, nil when the body is not a JSON object.
+	Body    map[string]any
+	RawBody string
+}
+
+func (e *RavelError) Error() string {
+	msg := fmt.Sprintf("error communicating with Ravel. URL: %s - %d", e.URL, e.StatusCode)
+
+	if e.Code != "" {
+		msg += fmt.Sprintf(" (%s)", e.Code)
+	}
+
+	if e.Message != "" {
+		msg += fmt.Sprintf(". Message: %s", e.Message)
+	} else if e.RawBody != "" {
+		msg += fmt.Sprintf(". Response: %s", e.RawBody)
+	}
+
+	if e.RequestId != "" {
+		msg += fmt.Sprintf(". Request id: %s", e.RequestId)
+	}
+
+	return msg
+}
+
+// VersionConflictError is returned when a configuration was updated while its latest version was not the expected one.
+type VersionConflictError struct {
+	ConfigId        string
+	ExpectedVersion int64
+	Err             error
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("configuration %s is no longer at version %d: %s", e.ConfigId, e.ExpectedVersion, e.Err.Error())
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is a Ravel API error with a 404 status.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a Ravel API error with a 409 or 412 status.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict) || hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsRetryable reports whether err is a Ravel API error that may succeed if the request is sent again.
+func IsRetryable(err error) bool {
+	var ravelErr *RavelError
+	return errors.As(err, &ravelErr) && ravelErr.Retryable
+}
+
+func hasStatus(err error, status int) bool {
+	var ravelErr *RavelError
+	return errors.As(err, &ravelErr) && ravelErr.StatusCode == status
+}
+
+func newRavelError(res *resty.Response) *RavelError {
+	ravelErr := &RavelError{
+		StatusCode: res.StatusCode(),
+		RawBody:    string(res.Body()),
+		RequestId:  res.Header().Get(requestIdHeader),
+	}
+
+	if res.Request != nil {
+		ravelErr.URL = res.Request.URL
+
+		if ravelErr.RequestId == "" {
+			ravelErr.RequestId = res.Request.Header.Get(requestIdHeader)
+		}
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(res.Body(), &body); err == nil {
+		ravelErr.Body = body
+		ravelErr.Code = firstString(body, "code", "error_code", "errorCode")
+		ravelErr.Message = firstString(body, "message", "error", "detail")
+
+		if retryable, ok := body["retryable"].(bool); ok {
+			ravelErr.Retryable = retryable
+		}
+	}
+
+	switch ravelErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		ravelErr.Retryable = true
+	}
+
+	return ravelErr
+}
+
+func firstString(body map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if val, ok := body[key].(string); ok && val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
@@ -30,12 +30,8 @@ func (rc *RavelClient) CreateConfig(c context.Context, meta models.RavelConfigMe
 		},
 	}
 
-	var definition []byte
-	definition, err := json.Marshal(ravelConfig)
-	if err != nil {
-		return nil, err
-	}
-	tflog.Info(c, fmt.Sprintf("Create config: %s", string(definition)))
+	// The definition may hold plaintext secrets, only the name is logged
+	tflog.Debug(c, fmt.Sprintf("Create config: %s", meta.Name))
 	res, err := rc.httpClient.R().SetContext(c).SetBody(ravelConfig).Post("/configurations")
 	if err != nil {
 		return nil, err
@@ -44,6 +40,49 @@ func (rc *RavelClient) CreateConfig(c context.Context, meta models.RavelConfigMe
 	return rc.configProcess(res, err)
 }
 
+// UpdateConfig publishes a new version of an existing configuration. The update is rejected with a
+// *VersionConflictError when the latest version of the configuration is no longer expectedVersion.
+func (rc *RavelClient) UpdateConfig(c context.Context, configId string, expectedVersion int64, meta models.RavelConfigMeta, configFormat *models.RavelSchemaMeta, configDef map[string]any) (*models.RavelConfig, error) {
+	meta.Version = expectedVersion
+	ravelConfig := models.RavelConfig{
+		Id:   configId,
+		Meta: meta,
+		Spec: models.RavelConfigSpec{
+			ConfigurationFormat: configFormat,
+			Def:                 configDef,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Update config: %s expecting version: %d", configId, expectedVersion))
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"configId": configId,
+	}).SetHeader("If-Match", strconv.Quote(strconv.FormatInt(expectedVersion, 10))).SetBody(ravelConfig).Put("/configurations/{configId}")
+
+	config, err := rc.configProcess(res, err)
+	if IsConflict(err) {
+		return nil, &VersionConflictError{ConfigId: configId, ExpectedVersion: expectedVersion, Err: err}
+	}
+
+	return config, err
+}
+
+// UpdateConfigLabels sets and removes labels of an existing configuration without publishing a new version.
+// Labels are applied as a JSON merge patch (RFC 7396), nil values remove their key and other keys are left untouched.
+func (rc *RavelClient) UpdateConfigLabels(c context.Context, configId string, labels map[string]*string) (*models.RavelConfig, error) {
+	patch := map[string]any{
+		"meta": map[string]any{
+			"labels": labels,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Update labels of config: %s", configId))
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"configId": configId,
+	}).SetHeader("Content-Type", "application/merge-patch+json").SetBody(patch).Patch("/configurations/{configId}")
+
+	return rc.configProcess(res, err)
+}
+
 func (rc *RavelClient) DeleteConfig(c context.Context, configId string) error {
 	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
 		"configId": configId,
@@ -52,15 +91,78 @@ func (rc *RavelClient) DeleteConfig(c context.Context, configId string) error {
 	return rc.handleError(res, err)
 }
 
+// GetConfigVersion returns the given configuration version, secrets are returned as secret:// references.
 func (rc *RavelClient) GetConfigVersion(c context.Context, configId string, version int) (*models.RavelConfig, error) {
 	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
 		"configId": configId,
 		"version":  strconv.Itoa(version),
-	}).Get("/configurations/{configId}/versions/{version}?secrets=resolve")
+	}).Get("/configurations/{configId}/versions/{version}")
+
+	return rc.configProcess(res, err)
+}
+
+// GetResolvedConfigVersion returns the given configuration version with its secret references resolved to plaintext.
+func (rc *RavelClient) GetResolvedConfigVersion(c context.Context, configId string, version int) (*models.RavelConfig, error) {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"configId": configId,
+		"version":  strconv.Itoa(version),
+	}).SetQueryParam("secrets", "resolve").Get("/configurations/{configId}/versions/{version}")
+
+	return rc.configProcess(res, err)
+}
+
+// GetLatestConfig returns the latest version of the given configuration, secrets are returned as secret:// references.
+func (rc *RavelClient) GetLatestConfig(c context.Context, configId string) (*models.RavelConfig, error) {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"configId": configId,
+	}).Get("/configurations/{configId}")
 
 	return rc.configProcess(res, err)
 }
 
+// FindConfigs returns the latest version of every configuration matching the given name and scope.
+func (rc *RavelClient) FindConfigs(c context.Context, name string, scope models.Scope) ([]models.RavelConfig, error) {
+	req := rc.httpClient.R().SetContext(c).SetQueryParam("name", name)
+	for key, val := range scope {
+		req.SetQueryParam("scope."+key, val)
+	}
+
+	res, err := req.Get("/configurations")
+	if err := rc.handleError(res, err); err != nil {
+		return nil, err
+	}
+
+	var configs []models.RavelConfig
+	if err := json.Unmarshal(res.Body(), &configs); err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+// FindSchemas returns every configuration format (schema) matching the given name, version and scope.
+func (rc *RavelClient) FindSchemas(c context.Context, meta models.RavelSchemaMeta) ([]models.RavelSchema, error) {
+	req := rc.httpClient.R().SetContext(c).SetQueryParam("name", meta.Name)
+	if meta.Version != "" {
+		req.SetQueryParam("version", meta.Version)
+	}
+	for key, val := range meta.Scope {
+		req.SetQueryParam("scope."+key, val)
+	}
+
+	res, err := req.Get("/schemas")
+	if err := rc.handleError(res, err); err != nil {
+		return nil, err
+	}
+
+	var schemas []models.RavelSchema
+	if err := json.Unmarshal(res.Body(), &schemas); err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
+}
+
 func (rc *RavelClient) configProcess(res *resty.Response, err error) (*models.RavelConfig, error) {
 	if err := rc.handleError(res, err); err != nil {
 		return nil, err
@@ -80,7 +182,7 @@ func (rc *RavelClient) handleError(res *resty.Response, err error) error {
 	}
 
 	if res.IsError() {
-		return fmt.Errorf("error communicating with Ravel. URL: %s - %d. Response: %s", res.Request.URL, res.StatusCode(), string(res.Body()))
+		return newRavelError(res)
 	}
 
 	return nil
@@ -0,0 +1,346 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.RavelClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return client.New(ravelhttp.New(server.URL, "test", "token"))
+}
+
+func TestGetConfigVersionNotFound(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": "CONFIGURATION_NOT_FOUND", "message": "configuration not found"}`))
+	})
+
+	_, err := rc.GetConfigVersion(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04", 0)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !client.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %s", err)
+	}
+
+	if client.IsConflict(err) || client.IsRetryable(err) {
+		t.Fatalf("not found error must not be a conflict nor retryable: %s", err)
+	}
+
+	ravelErr, ok := err.(*client.RavelError)
+	if !ok {
+		t.Fatalf("expected *client.RavelError, got: %T", err)
+	}
+
+	if ravelErr.Code != "CONFIGURATION_NOT_FOUND" || ravelErr.Message != "configuration not found" || ravelErr.RequestId != "req-123" {
+		t.Fatalf("unexpected error fields: %+v", ravelErr)
+	}
+}
+
+func TestDeleteConfigRetryable(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("upstream unavailable"))
+	})
+
+	err := rc.DeleteConfig(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04")
+	if !client.IsRetryable(err) {
+		t.Fatalf("expected a retryable error, got: %v", err)
+	}
+
+	if client.IsNotFound(err) {
+		t.Fatalf("unexpected not found error: %s", err)
+	}
+}
+
+func TestUpdateConfigVersionConflict(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("If-Match") != "\"3\"" {
+			t.Errorf("unexpected request: %s %s If-Match: %s", r.Method, r.URL.Path, r.Header.Get("If-Match"))
+		}
+
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code": "VERSION_CONFLICT", "message": "latest version is 4"}`))
+	})
+
+	_, err := rc.UpdateConfig(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04", 3, models.RavelConfigMeta{}, nil, map[string]any{})
+
+	var conflictErr *client.VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected *client.VersionConflictError, got: %v", err)
+	}
+
+	if conflictErr.ExpectedVersion != 3 || !client.IsConflict(err) {
+		t.Fatalf("unexpected conflict error: %+v", conflictErr)
+	}
+}
+
+func TestUpdateConfigIfMatch(t *testing.T) {
+	var ifMatch string
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = r.Header.Get("If-Match")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04", "meta": {"name": "smtp", "version": 13}, "spec": {"def": {}}}`))
+	})
+
+	config, err := rc.UpdateConfig(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04", 12, models.RavelConfigMeta{}, nil, map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Entity tags are quoted strings (RFC 9110)
+	if ifMatch != `"12"` {
+		t.Fatalf("unexpected If-Match header: %s", ifMatch)
+	}
+
+	if config.Meta.Version != 13 {
+		t.Fatalf("unexpected version: %d", config.Meta.Version)
+	}
+}
+
+func TestCreateSchema(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/schemas" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body models.RavelSchema
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+
+		if body.Meta.Name != "smtp" || body.Meta.Version != "1.0.0" || body.Spec.Def["type"] != "object" {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		body.Id = "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21"
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	meta := models.RavelSchemaMeta{
+		RavelResourceMeta: models.RavelResourceMeta{Name: "smtp"},
+		Version:           "1.0.0",
+	}
+
+	created, err := rc.CreateSchema(context.Background(), meta, map[string]any{"type": "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Id != "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21" || created.Meta.Version != "1.0.0" {
+		t.Fatalf("unexpected schema: %+v", created)
+	}
+}
+
+func TestCreateSecret(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/secrets/smtp" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body models.RavelSecret
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+
+		if body.Meta.Store != "smtp" || body.Spec.Value != "hunter2" {
+			t.Errorf("unexpected request body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21", "meta": {"store": "smtp"}, "spec": {}}`))
+	})
+
+	created, err := rc.CreateSecret(context.Background(), models.RavelSecretMeta{Store: "smtp"}, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Id != "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21" || created.Spec.Value != "" {
+		t.Fatalf("unexpected secret: %+v", created)
+	}
+}
+
+func TestUpdateConfigLabels(t *testing.T) {
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			t.Errorf("unexpected request: %s %s Content-Type: %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"meta":{"labels":{"minDominoVersion":"005.009.000","minSchemaVersion":null}}}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04", "meta": {"name": "smtp", "version": 3, "labels": {"minDominoVersion": "005.009.000"}}, "spec": {"def": {}}}`))
+	})
+
+	version := "005.009.000"
+	config, err := rc.UpdateConfigLabels(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04", map[string]*string{
+		"minDominoVersion": &version,
+		"minSchemaVersion": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Meta.Version != 3 || config.Meta.Labels["minDominoVersion"] != "005.009.000" {
+		t.Fatalf("unexpected configuration: %+v", config)
+	}
+}
+
+func TestBatchConfigs(t *testing.T) {
+	requests := 0
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Operations []models.RavelConfigOperation `json:"operations"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/configurations/batch" || len(body.Operations) > client.ConfigBatchSize {
+			t.Errorf("unexpected request: %s %s with %d operations", r.Method, r.URL.Path, len(body.Operations))
+		}
+
+		results := make([]models.RavelConfigOperationResult, 0, len(body.Operations))
+		for _, op := range body.Operations {
+			if op.Op == client.ConfigOpUpdate {
+				results = append(results, models.RavelConfigOperationResult{Status: http.StatusConflict, Code: "VERSION_CONFLICT"})
+				continue
+			}
+
+			results = append(results, models.RavelConfigOperationResult{Status: http.StatusCreated, Config: op.Config})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	})
+
+	ops := make([]models.RavelConfigOperation, 0, client.ConfigBatchSize+1)
+	for i := 0; i < client.ConfigBatchSize; i++ {
+		ops = append(ops, models.RavelConfigOperation{Op: client.ConfigOpCreate, Config: &models.RavelConfig{Meta: models.RavelConfigMeta{RavelResourceMeta: models.RavelResourceMeta{Name: "smtp"}}}})
+	}
+	expectedVersion := int64(3)
+	ops = append(ops, models.RavelConfigOperation{Op: client.ConfigOpUpdate, Id: "7eb918e0-49b6-4519-bb5a-850c42d8da04", ExpectedVersion: &expectedVersion})
+
+	results, err := rc.BatchConfigs(context.Background(), ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 || len(results) != len(ops) {
+		t.Fatalf("expected %d results in 2 requests, got %d results in %d requests", len(ops), len(results), requests)
+	}
+
+	if results[0].Err != nil || results[0].Config == nil || results[0].Config.Meta.Name != "smtp" {
+		t.Errorf("unexpected create result: %+v", results[0])
+	}
+
+	var conflictErr *client.VersionConflictError
+	if last := results[len(results)-1]; !errors.As(last.Err, &conflictErr) || conflictErr.ExpectedVersion != 3 {
+		t.Errorf("expected a version conflict, got: %v", last.Err)
+	}
+}
+
+func TestBatchConfigsPartialFailure(t *testing.T) {
+	requests := 0
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Operations []models.RavelConfigOperation `json:"operations"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if requests == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": "INVALID_BATCH", "message": "invalid batch"}`))
+			return
+		}
+
+		results := make([]models.RavelConfigOperationResult, 0, len(body.Operations))
+		for _, op := range body.Operations {
+			results = append(results, models.RavelConfigOperationResult{Status: http.StatusCreated, Config: op.Config})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	})
+
+	ops := make([]models.RavelConfigOperation, 0, client.ConfigBatchSize+1)
+	for i := 0; i <= client.ConfigBatchSize; i++ {
+		ops = append(ops, models.RavelConfigOperation{Op: client.ConfigOpCreate, Config: &models.RavelConfig{Meta: models.RavelConfigMeta{RavelResourceMeta: models.RavelResourceMeta{Name: "smtp"}}}})
+	}
+
+	results, err := rc.BatchConfigs(context.Background(), ops)
+
+	var ravelErr *client.RavelError
+	if !errors.As(err, &ravelErr) || ravelErr.Code != "INVALID_BATCH" {
+		t.Fatalf("expected the error of the second batch, got: %v", err)
+	}
+
+	if requests != 2 || len(results) != client.ConfigBatchSize {
+		t.Fatalf("expected the %d results of the first batch, got %d results in %d requests", client.ConfigBatchSize, len(results), requests)
+	}
+
+	if results[0].Err != nil || results[0].Config == nil {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+}
+
+func TestBatchConfigsUpdateFromFirstVersion(t *testing.T) {
+	var operations []map[string]json.RawMessage
+	rc := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Operations []map[string]json.RawMessage `json:"operations"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+		operations = body.Operations
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": [{"status": 200, "config": {"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04", "meta": {"name": "smtp", "version": 1}}}, {"status": 201}]}`))
+	})
+
+	firstVersion := int64(0)
+	_, err := rc.BatchConfigs(context.Background(), []models.RavelConfigOperation{
+		{Op: client.ConfigOpUpdate, Id: "7eb918e0-49b6-4519-bb5a-850c42d8da04", ExpectedVersion: &firstVersion, Config: &models.RavelConfig{}},
+		{Op: client.ConfigOpCreate, Config: &models.RavelConfig{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(operations) != 2 || string(operations[0]["expectedVersion"]) != "0" {
+		t.Fatalf("expected the update to send \"expectedVersion\":0, got: %v", operations)
+	}
+
+	if _, ok := operations[1]["expectedVersion"]; ok {
+		t.Errorf("expected the create to send no expected version, got: %s", operations[1]["expectedVersion"])
+	}
+}
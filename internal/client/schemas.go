@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CreateSchema publishes a new configuration format.
+func (rc *RavelClient) CreateSchema(c context.Context, meta models.RavelSchemaMeta, schemaDef map[string]any) (*models.RavelSchema, error) {
+	ravelSchema := models.RavelSchema{
+		Meta: meta,
+		Spec: models.RavelSchemaSpec{
+			Def: schemaDef,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Create schema: %s version: %s", meta.Name, meta.Version))
+	res, err := rc.httpClient.R().SetContext(c).SetBody(ravelSchema).Post("/schemas")
+
+	return rc.schemaProcess(res, err)
+}
+
+// UpdateSchema replaces the labels and the JSON Schema document of an existing configuration format.
+func (rc *RavelClient) UpdateSchema(c context.Context, schemaId string, meta models.RavelSchemaMeta, schemaDef map[string]any) (*models.RavelSchema, error) {
+	ravelSchema := models.RavelSchema{
+		Id:   schemaId,
+		Meta: meta,
+		Spec: models.RavelSchemaSpec{
+			Def: schemaDef,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Update schema: %s", schemaId))
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"schemaId": schemaId,
+	}).SetBody(ravelSchema).Put("/schemas/{schemaId}")
+
+	return rc.schemaProcess(res, err)
+}
+
+func (rc *RavelClient) GetSchema(c context.Context, schemaId string) (*models.RavelSchema, error) {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"schemaId": schemaId,
+	}).Get("/schemas/{schemaId}")
+
+	return rc.schemaProcess(res, err)
+}
+
+func (rc *RavelClient) DeleteSchema(c context.Context, schemaId string) error {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"schemaId": schemaId,
+	}).Delete("/schemas/{schemaId}")
+
+	return rc.handleError(res, err)
+}
+
+func (rc *RavelClient) schemaProcess(res *resty.Response, err error) (*models.RavelSchema, error) {
+	if err := rc.handleError(res, err); err != nil {
+		return nil, err
+	}
+
+	var schema *models.RavelSchema
+	if err := json.Unmarshal(res.Body(), &schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
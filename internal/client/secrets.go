@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CreateSecret stores a new secret value in the given store. The value is never logged nor returned.
+func (rc *RavelClient) CreateSecret(c context.Context, meta models.RavelSecretMeta, value string) (*models.RavelSecret, error) {
+	ravelSecret := models.RavelSecret{
+		Meta: meta,
+		Spec: models.RavelSecretSpec{
+			Value: value,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Create secret in store: %s", meta.Store))
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"store": meta.Store,
+	}).SetBody(ravelSecret).Post("/secrets/{store}")
+
+	return rc.secretProcess(res, err)
+}
+
+// UpdateSecret rotates the value and replaces the labels of an existing secret, its reference is unchanged.
+func (rc *RavelClient) UpdateSecret(c context.Context, secretId string, meta models.RavelSecretMeta, value string) (*models.RavelSecret, error) {
+	ravelSecret := models.RavelSecret{
+		Id:   secretId,
+		Meta: meta,
+		Spec: models.RavelSecretSpec{
+			Value: value,
+		},
+	}
+
+	tflog.Info(c, fmt.Sprintf("Update secret: %s in store: %s", secretId, meta.Store))
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"store":    meta.Store,
+		"secretId": secretId,
+	}).SetBody(ravelSecret).Put("/secrets/{store}/{secretId}")
+
+	return rc.secretProcess(res, err)
+}
+
+// GetSecret returns the metadata of a secret, its value is never returned.
+func (rc *RavelClient) GetSecret(c context.Context, store string, secretId string) (*models.RavelSecret, error) {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"store":    store,
+		"secretId": secretId,
+	}).Get("/secrets/{store}/{secretId}")
+
+	return rc.secretProcess(res, err)
+}
+
+func (rc *RavelClient) DeleteSecret(c context.Context, store string, secretId string) error {
+	res, err := rc.httpClient.R().SetContext(c).SetPathParams(map[string]string{
+		"store":    store,
+		"secretId": secretId,
+	}).Delete("/secrets/{store}/{secretId}")
+
+	return rc.handleError(res, err)
+}
+
+func (rc *RavelClient) secretProcess(res *resty.Response, err error) (*models.RavelSecret, error) {
+	if err := rc.handleError(res, err); err != nil {
+		return nil, err
+	}
+
+	var secret *models.RavelSecret
+	if err := json.Unmarshal(res.Body(), &secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,61 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure DocumentType satisfies the framework custom type interfaces.
+var _ basetypes.StringTypable = DocumentType{}
+
+// DocumentType is a string type holding a document of the given format. Document is the associated value type.
+type DocumentType struct {
+	basetypes.StringType
+	Format formats.Format
+}
+
+func (t DocumentType) String() string {
+	return fmt.Sprintf("customtypes.DocumentType[%s]", t.Format)
+}
+
+func (t DocumentType) ValueType(ctx context.Context) attr.Value {
+	return Document{Format: t.Format}
+}
+
+func (t DocumentType) Equal(o attr.Type) bool {
+	other, ok := o.(DocumentType)
+	if !ok {
+		return false
+	}
+
+	return t.Format == other.Format && t.StringType.Equal(other.StringType)
+}
+
+func (t DocumentType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Document{StringValue: in, Format: t.Format}, nil
+}
+
+func (t DocumentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
@@ -0,0 +1,67 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure Document satisfies the framework custom value interfaces.
+var _ basetypes.StringValuableWithSemanticEquals = Document{}
+
+// Document is a string value holding a YAML, TOML or JSON with comments document. Two values are
+// semantically equal when they decode to the same content, regardless of comments or layout.
+type Document struct {
+	basetypes.StringValue
+	Format formats.Format
+}
+
+func NewDocumentNull(format formats.Format) Document {
+	return Document{StringValue: basetypes.NewStringNull(), Format: format}
+}
+
+func NewDocumentValue(format formats.Format, value string) Document {
+	return Document{StringValue: basetypes.NewStringValue(value), Format: format}
+}
+
+func (v Document) Type(ctx context.Context) attr.Type {
+	return DocumentType{Format: v.Format}
+}
+
+func (v Document) Equal(o attr.Value) bool {
+	other, ok := o.(Document)
+	if !ok {
+		return false
+	}
+
+	return v.Format == other.Format && v.StringValue.Equal(other.StringValue)
+}
+
+func (v Document) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(Document)
+	if !ok || newValue.Format != v.Format {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: %s\n"+
+				"Got Value Type: %s", v.Type(ctx), newValuable.Type(ctx)),
+		)
+
+		return false, diags
+	}
+
+	equal, err := formats.SemanticallyEqual(v.Format, v.ValueString(), newValue.ValueString())
+	if err != nil {
+		// Invalid documents are reported by validation, fall back to a plain comparison here.
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return equal, diags
+}
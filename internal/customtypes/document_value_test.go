@@ -0,0 +1,62 @@
+package customtypes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+)
+
+func TestDocumentStringSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		format   formats.Format
+		current  string
+		new      string
+		expected bool
+	}{
+		"yaml formatting": {
+			format:   formats.YAML,
+			current:  "port: 465\nauth:\n  username: user\n",
+			new:      "auth: {username: user}\nport: 465\n",
+			expected: true,
+		},
+		"yaml changed value": {
+			format:   formats.YAML,
+			current:  "port: 465\n",
+			new:      "port: 587\n",
+			expected: false,
+		},
+		"toml formatting": {
+			format:   formats.TOML,
+			current:  "port = 465\n[auth]\nusername = \"user\"\n",
+			new:      "port = 465\n\n[auth]\n  username = 'user'\n",
+			expected: true,
+		},
+		"jsonc comments": {
+			format:   formats.JSONC,
+			current:  `{"port":465}`,
+			new:      "{\n  // submission port\n  \"port\": 465,\n}",
+			expected: true,
+		},
+		"invalid document": {
+			format:   formats.YAML,
+			current:  "port: 465\n",
+			new:      "port: [",
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := customtypes.NewDocumentValue(testCase.format, testCase.current).StringSemanticEquals(context.Background(), customtypes.NewDocumentValue(testCase.format, testCase.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package customtypes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure JSON satisfies the framework custom value interfaces.
+var _ basetypes.StringValuableWithSemanticEquals = JSON{}
+
+// JSON is a string value holding a JSON document. Two values are semantically equal when
+// they decode to the same JSON content, regardless of whitespace or object key ordering.
+type JSON struct {
+	basetypes.StringValue
+}
+
+func NewJSONNull() JSON {
+	return JSON{StringValue: basetypes.NewStringNull()}
+}
+
+func NewJSONUnknown() JSON {
+	return JSON{StringValue: basetypes.NewStringUnknown()}
+}
+
+func NewJSONValue(value string) JSON {
+	return JSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSON) Type(ctx context.Context) attr.Type {
+	return JSONType{}
+}
+
+func (v JSON) Equal(o attr.Value) bool {
+	other, ok := o.(JSON)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v JSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: %T\n"+
+				"Got Value Type: %T", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	equal, err := SemanticallyEqualJSON(v.ValueString(), newValue.ValueString())
+	if err != nil {
+		// Invalid JSON is reported by validation, fall back to a plain comparison here.
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return equal, diags
+}
+
+// SemanticallyEqualJSON reports whether both documents decode to the same JSON content. Numbers are compared
+// exactly, so large integers that do not fit a float64 are still told apart.
+func SemanticallyEqualJSON(a, b string) (bool, error) {
+	if a == b {
+		return true, nil
+	}
+
+	aDecoded, err := decodeJSON(a)
+	if err != nil {
+		return false, err
+	}
+
+	bDecoded, err := decodeJSON(b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aDecoded, bDecoded), nil
+}
+
+// exactNumber is the canonical rational representation of a JSON number, such as 465 for both 465 and 465.0.
+type exactNumber string
+
+// decodeJSON decodes a single JSON document, its numbers decoded as exactNumber.
+func decodeJSON(document string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid content after the JSON document")
+	}
+
+	return exactNumbers(decoded), nil
+}
+
+// exactNumbers replaces the json.Number values of value by their exactNumber.
+func exactNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = exactNumbers(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = exactNumbers(elem)
+		}
+	case json.Number:
+		if number, ok := new(big.Rat).SetString(v.String()); ok {
+			return exactNumber(number.RatString())
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,75 @@
+package customtypes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+)
+
+func TestJSONStringSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		current  string
+		new      string
+		expected bool
+	}{
+		"identical": {
+			current:  `{"a":1}`,
+			new:      `{"a":1}`,
+			expected: true,
+		},
+		"whitespace and key order": {
+			current:  `{"port":465,"auth":{"username":"user","password":"password"}}`,
+			new:      "{\n  \"auth\": {\"password\": \"password\", \"username\": \"user\"},\n  \"port\": 465\n}",
+			expected: true,
+		},
+		"number representation": {
+			current:  `{"port":465}`,
+			new:      `{"port":465.0}`,
+			expected: true,
+		},
+		"exponent representation": {
+			current:  `{"quota":10000}`,
+			new:      `{"quota":1e4}`,
+			expected: true,
+		},
+		"large integers": {
+			current:  `{"account":9007199254740993}`,
+			new:      `{"account":9007199254740992}`,
+			expected: false,
+		},
+		"number and string": {
+			current:  `{"port":465}`,
+			new:      `{"port":"465"}`,
+			expected: false,
+		},
+		"changed value": {
+			current:  `{"port":465}`,
+			new:      `{"port":587}`,
+			expected: false,
+		},
+		"array order": {
+			current:  `{"hosts":["a","b"]}`,
+			new:      `{"hosts":["b","a"]}`,
+			expected: false,
+		},
+		"invalid json": {
+			current:  `{"port":465}`,
+			new:      `{"port":`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := customtypes.NewJSONValue(testCase.current).StringSemanticEquals(context.Background(), customtypes.NewJSONValue(testCase.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if equal != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Package formats converts configuration definitions between JSON and the other document formats
+// accepted by the provider (YAML, TOML and JSON with comments).
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	JSON  Format = "json"
+	JSONC Format = "jsonc"
+	YAML  Format = "yaml"
+	TOML  Format = "toml"
+)
+
+// Decode parses document and returns its top-level object with JSON compatible values
+// (map[string]any, []any, float64, string, bool and nil).
+func Decode(format Format, document string) (map[string]any, error) {
+	var decoded any
+
+	switch format {
+	case JSON:
+		if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+			return nil, err
+		}
+	case JSONC:
+		if err := json.Unmarshal([]byte(StripJSONComments(document)), &decoded); err != nil {
+			return nil, err
+		}
+	case YAML:
+		if err := yaml.Unmarshal([]byte(document), &decoded); err != nil {
+			return nil, err
+		}
+	case TOML:
+		var table map[string]any
+		if _, err := toml.Decode(document, &table); err != nil {
+			return nil, err
+		}
+		decoded = table
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	// Round trip through JSON so every format yields the same value types.
+	normalized, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s document cannot be represented as JSON: %w", format, err)
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(normalized, &object); err != nil || object == nil {
+		return nil, fmt.Errorf("the top-level %s value must be an object", format)
+	}
+
+	return object, nil
+}
+
+// Encode renders value as a document of the given format.
+func Encode(format Format, value map[string]any) (string, error) {
+	switch format {
+	case JSON:
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	case JSONC:
+		encoded, err := json.MarshalIndent(value, "", "  ")
+		return string(encoded), err
+	case YAML:
+		encoded, err := yaml.Marshal(integralNumbers(value))
+		return string(encoded), err
+	case TOML:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(integralNumbers(value)); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// SemanticallyEqual reports whether both documents decode to the same content.
+func SemanticallyEqual(format Format, a, b string) (bool, error) {
+	if a == b {
+		return true, nil
+	}
+
+	aDecoded, err := Decode(format, a)
+	if err != nil {
+		return false, err
+	}
+
+	bDecoded, err := Decode(format, b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aDecoded, bDecoded), nil
+}
+
+// StripJSONComments removes `//` and `/* */` comments as well as trailing commas from a JSON document.
+// Line breaks are kept so positions reported by the JSON decoder still match the original document.
+func StripJSONComments(document string) string {
+	var out strings.Builder
+	inString, escaped := false, false
+
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+
+		if inString {
+			out.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(document) && document[i+1] == '/':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+			if i < len(document) {
+				out.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(document) && document[i+1] == '*':
+			i += 2
+			for i < len(document) && !(document[i] == '*' && i+1 < len(document) && document[i+1] == '/') {
+				if document[i] == '\n' {
+					out.WriteByte('\n')
+				}
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			trimmed := strings.TrimRight(out.String(), " \t\r\n")
+			if strings.HasSuffix(trimmed, ",") {
+				rest := out.String()[len(trimmed):]
+				out.Reset()
+				out.WriteString(trimmed[:len(trimmed)-1])
+				out.WriteString(rest)
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return out.String()
+}
+
+// integralNumbers converts whole float64 values to int64 so formats distinguishing integers render them as such.
+func integralNumbers(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		converted := make(map[string]any, len(typed))
+		for key, elem := range typed {
+			converted[key] = integralNumbers(elem)
+		}
+		return converted
+	case []any:
+		converted := make([]any, len(typed))
+		for index, elem := range typed {
+			converted[index] = integralNumbers(elem)
+		}
+		return converted
+	case float64:
+		if typed == math.Trunc(typed) && math.Abs(typed) < 1<<53 {
+			return int64(typed)
+		}
+		return typed
+	default:
+		return value
+	}
+}
@@ -0,0 +1,99 @@
+package formats_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+)
+
+var expected = map[string]any{
+	"email_notifications": map[string]any{
+		"enabled": true,
+		"port":    float64(465),
+		"servers": []any{"a", "b"},
+		"authentication": map[string]any{
+			"username": "user",
+		},
+	},
+}
+
+func TestDecode(t *testing.T) {
+	documents := map[formats.Format]string{
+		formats.JSON: `{"email_notifications": {"enabled": true, "port": 465, "servers": ["a", "b"], "authentication": {"username": "user"}}}`,
+		formats.JSONC: `{
+			// SMTP settings
+			"email_notifications": {
+				"enabled": true, /* set to false to disable */
+				"port": 465,
+				"servers": ["a", "b",],
+				"authentication": {"username": "user"},
+			},
+		}`,
+		formats.YAML: `
+# SMTP settings
+email_notifications:
+  enabled: true
+  port: 465
+  servers: [a, b]
+  authentication:
+    username: user
+`,
+		formats.TOML: `
+# SMTP settings
+[email_notifications]
+enabled = true
+port = 465
+servers = ["a", "b"]
+
+[email_notifications.authentication]
+username = "user"
+`,
+	}
+
+	for format, document := range documents {
+		t.Run(string(format), func(t *testing.T) {
+			decoded, err := formats.Decode(format, document)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Fatalf("expected %v, got %v", expected, decoded)
+			}
+		})
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, format := range []formats.Format{formats.JSON, formats.JSONC, formats.YAML, formats.TOML} {
+		t.Run(string(format), func(t *testing.T) {
+			encoded, err := formats.Encode(format, expected)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			decoded, err := formats.Decode(format, encoded)
+			if err != nil {
+				t.Fatalf("unexpected error decoding %q: %s", encoded, err)
+			}
+
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Fatalf("expected %v, got %v", expected, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeNotObject(t *testing.T) {
+	if _, err := formats.Decode(formats.YAML, "- a\n- b\n"); err == nil {
+		t.Fatal("expected an error for a YAML sequence")
+	}
+}
+
+func TestStripJSONCommentsKeepsStrings(t *testing.T) {
+	document := `{"url": "http://host/*path*/", "note": "a // b"}`
+	if stripped := formats.StripJSONComments(document); stripped != document {
+		t.Fatalf("expected strings to be kept, got %s", stripped)
+	}
+}
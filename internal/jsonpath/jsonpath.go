@@ -0,0 +1,210 @@
+// Package jsonpath addresses values inside decoded JSON documents (map[string]any, []any and scalars)
+// using dotted paths such as `email_notifications.authentication.password` or `servers[0].host`.
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Segment is a single step of a path, either an object key or an array index.
+type Segment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// Parse splits a dotted path into its segments. Keys containing `.`, `[` or `]` can be written as `["key"]`.
+func Parse(path string) ([]Segment, error) {
+	var segments []Segment
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 {
+				return nil, fmt.Errorf("invalid path %q: unexpected '.' at position %d", path, i)
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated '[' at position %d", path, i)
+			}
+
+			inner := path[i+1 : i+end]
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				segments = append(segments, Segment{Key: unquoted})
+			} else if index, err := strconv.Atoi(inner); err == nil && index >= 0 {
+				segments = append(segments, Segment{Index: index, IsIndex: true})
+			} else {
+				return nil, fmt.Errorf("invalid path %q: %q is neither an array index nor a quoted key", path, inner)
+			}
+
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+
+			segments = append(segments, Segment{Key: path[i : i+end]})
+			i += end
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid path %q: path is empty", path)
+	}
+
+	return segments, nil
+}
+
+// Join renders segments back into a dotted path.
+func Join(segments []Segment) string {
+	var b strings.Builder
+
+	for _, segment := range segments {
+		switch {
+		case segment.IsIndex:
+			fmt.Fprintf(&b, "[%d]", segment.Index)
+		case segment.Key == "" || strings.ContainsAny(segment.Key, ".[]\""):
+			fmt.Fprintf(&b, "[%q]", segment.Key)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(segment.Key)
+		}
+	}
+
+	return b.String()
+}
+
+// Get returns the value found at path in doc.
+func Get(doc any, path string) (any, bool) {
+	segments, err := Parse(path)
+	if err != nil {
+		return nil, false
+	}
+
+	current := doc
+	for _, segment := range segments {
+		next, ok := step(current, segment)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	return current, true
+}
+
+// Set replaces the value found at path in doc. Only existing locations can be replaced.
+func Set(doc any, path string, value any) bool {
+	segments, err := Parse(path)
+	if err != nil {
+		return false
+	}
+
+	current := doc
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := step(current, segment)
+		if !ok {
+			return false
+		}
+		current = next
+	}
+
+	last := segments[len(segments)-1]
+	switch container := current.(type) {
+	case map[string]any:
+		if last.IsIndex {
+			return false
+		}
+		if _, ok := container[last.Key]; !ok {
+			return false
+		}
+		container[last.Key] = value
+	case []any:
+		if !last.IsIndex || last.Index >= len(container) {
+			return false
+		}
+		container[last.Index] = value
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Walk calls fn for every scalar (non object, non array) value of doc, in a stable order.
+func Walk(doc any, fn func(path string, value any)) {
+	walk(doc, nil, fn)
+}
+
+func walk(doc any, segments []Segment, fn func(path string, value any)) {
+	switch value := doc.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			walk(value[key], append(segments, Segment{Key: key}), fn)
+		}
+	case []any:
+		for index, elem := range value {
+			walk(elem, append(segments, Segment{Index: index, IsIndex: true}), fn)
+		}
+	default:
+		fn(Join(segments), value)
+	}
+}
+
+func step(current any, segment Segment) (any, bool) {
+	switch container := current.(type) {
+	case map[string]any:
+		if segment.IsIndex {
+			return nil, false
+		}
+		next, ok := container[segment.Key]
+		return next, ok
+	case []any:
+		if !segment.IsIndex || segment.Index >= len(container) {
+			return nil, false
+		}
+		return container[segment.Index], true
+	default:
+		return nil, false
+	}
+}
+
+// FromPointer converts a JSON pointer (RFC 6901) addressing a value of doc into a dotted path.
+// Numeric pointer tokens are treated as array indexes only when doc holds an array at that location.
+func FromPointer(doc any, pointer string) string {
+	if pointer == "" || pointer == "/" {
+		return ""
+	}
+
+	var segments []Segment
+	current := doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		segment := Segment{Key: token}
+		if _, isArray := current.([]any); isArray {
+			if index, err := strconv.Atoi(token); err == nil {
+				segment = Segment{Index: index, IsIndex: true}
+			}
+		}
+
+		segments = append(segments, segment)
+		current, _ = step(current, segment)
+	}
+
+	return Join(segments)
+}
@@ -0,0 +1,100 @@
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+)
+
+const document = `{
+	"email_notifications": {
+		"authentication": {"username": "user", "password": "password"},
+		"servers": [{"host": "a"}, {"host": "b"}],
+		"with.dot": true
+	}
+}`
+
+func decode(t *testing.T) map[string]any {
+	t.Helper()
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	return doc
+}
+
+func TestWalk(t *testing.T) {
+	var paths []string
+	jsonpath.Walk(decode(t), func(path string, value any) {
+		paths = append(paths, path)
+	})
+
+	expected := []string{
+		"email_notifications.authentication.password",
+		"email_notifications.authentication.username",
+		"email_notifications.servers[0].host",
+		"email_notifications.servers[1].host",
+		`email_notifications["with.dot"]`,
+	}
+
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+}
+
+func TestGetAndSet(t *testing.T) {
+	doc := decode(t)
+
+	if value, ok := jsonpath.Get(doc, "email_notifications.servers[1].host"); !ok || value != "b" {
+		t.Fatalf("unexpected value: %v", value)
+	}
+
+	if value, ok := jsonpath.Get(doc, `email_notifications["with.dot"]`); !ok || value != true {
+		t.Fatalf("unexpected value: %v", value)
+	}
+
+	if _, ok := jsonpath.Get(doc, "email_notifications.servers[2].host"); ok {
+		t.Fatal("expected missing index to not be found")
+	}
+
+	if !jsonpath.Set(doc, "email_notifications.authentication.password", "secret://default/id") {
+		t.Fatal("expected existing path to be set")
+	}
+
+	if value, _ := jsonpath.Get(doc, "email_notifications.authentication.password"); value != "secret://default/id" {
+		t.Fatalf("unexpected value: %v", value)
+	}
+
+	if jsonpath.Set(doc, "email_notifications.authentication.token", "x") {
+		t.Fatal("expected missing path to not be set")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, path := range []string{"", ".a", "a.", "a[", "a[x]"} {
+		if _, err := jsonpath.Parse(path); err == nil {
+			t.Errorf("expected an error for path %q", path)
+		}
+	}
+}
+
+func TestFromPointer(t *testing.T) {
+	doc := decode(t)
+
+	testCases := map[string]string{
+		"": "",
+		"/email_notifications/authentication/port": "email_notifications.authentication.port",
+		"/email_notifications/servers/1/host":      "email_notifications.servers[1].host",
+		"/email_notifications/with.dot":            `email_notifications["with.dot"]`,
+	}
+
+	for pointer, expected := range testCases {
+		if path := jsonpath.FromPointer(doc, pointer); path != expected {
+			t.Errorf("expected %q for pointer %q, got %q", expected, pointer, path)
+		}
+	}
+}
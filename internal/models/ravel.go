@@ -4,6 +4,8 @@ type RavelConfig struct {
 	Id   string          `json:"id"`
 	Meta RavelConfigMeta `json:"meta"`
 	Spec RavelConfigSpec `json:"spec"`
+	// CreatedAt is the publication time of the version in seconds since the Unix epoch, set by Ravel.
+	CreatedAt int64 `json:"created_at,omitempty"`
 }
 
 type Labels map[string]string
@@ -29,3 +31,46 @@ type RavelConfigSpec struct {
 	ConfigurationFormat *RavelSchemaMeta `json:"configurationFormat,omitempty"`
 	Def                 map[string]any   `json:"def"`
 }
+
+type RavelSchema struct {
+	Id   string          `json:"id"`
+	Meta RavelSchemaMeta `json:"meta"`
+	Spec RavelSchemaSpec `json:"spec"`
+}
+
+type RavelSchemaSpec struct {
+	Def map[string]any `json:"def"`
+}
+
+type RavelSecret struct {
+	Id   string          `json:"id"`
+	Meta RavelSecretMeta `json:"meta"`
+	Spec RavelSecretSpec `json:"spec"`
+}
+
+type RavelSecretMeta struct {
+	Store  string `json:"store"`
+	Labels Labels `json:"labels,omitempty"`
+}
+
+type RavelSecretSpec struct {
+	// Value is only sent to Ravel, it is never returned.
+	Value string `json:"value,omitempty"`
+}
+
+// RavelConfigOperation is a single create, update or delete of a configuration batch.
+// ExpectedVersion is only set for updates, versions start at 0 so it is always sent when set.
+type RavelConfigOperation struct {
+	Op              string       `json:"op"`
+	Id              string       `json:"id,omitempty"`
+	ExpectedVersion *int64       `json:"expectedVersion,omitempty"`
+	Config          *RavelConfig `json:"config,omitempty"`
+}
+
+// RavelConfigOperationResult is the outcome of a RavelConfigOperation, with an HTTP like status.
+type RavelConfigOperationResult struct {
+	Status  int          `json:"status"`
+	Config  *RavelConfig `json:"config,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cerebrotech/terraform-provider-ravel/internal/http"
 	"github.com/cerebrotech/terraform-provider-ravel/internal/resources"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -38,8 +39,10 @@ type RavelProvider struct {
 
 // RavelProviderModel describes the provider data model.
 type RavelProviderModel struct {
-	URL   types.String `tfsdk:"url"`
-	Token types.String `tfsdk:"token"`
+	URL           types.String `tfsdk:"url"`
+	Token         types.String `tfsdk:"token"`
+	DefaultLabels types.Map    `tfsdk:"default_labels"`
+	DefaultScope  types.Map    `tfsdk:"default_scope"`
 }
 
 func (p RavelProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -59,6 +62,16 @@ func (p RavelProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"default_labels": schema.MapAttribute{
+				Description: "Labels merged into the labels of every configuration, the labels set on a configuration take precedence",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_scope": schema.MapAttribute{
+				Description: "Scope merged into the scope of every configuration, the scope set on a configuration takes precedence",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -103,6 +116,9 @@ func (p RavelProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	defaultLabels := providerDefaults(ctx, path.Root("default_labels"), config.DefaultLabels, &resp.Diagnostics)
+	defaultScope := providerDefaults(ctx, path.Root("default_scope"), config.DefaultScope, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -111,12 +127,40 @@ func (p RavelProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	ravelClient := client.New(httpClient)
 
 	resp.DataSourceData = ravelClient
-	resp.ResourceData = ravelClient
+	resp.ResourceData = &resources.ProviderData{
+		Client:        ravelClient,
+		DefaultLabels: defaultLabels,
+		DefaultScope:  defaultScope,
+	}
+}
+
+// providerDefaults returns the map of defaults configured at attribute, which must be known at plan time.
+func providerDefaults(ctx context.Context, attribute path.Path, value types.Map, diagnostics *diag.Diagnostics) map[string]string {
+	if value.IsUnknown() {
+		diagnostics.AddAttributeError(
+			attribute,
+			"Unknown Ravel Provider Defaults",
+			fmt.Sprintf("The provider cannot merge %s into configurations as its value is not known until apply. "+
+				"Set it to values known at plan time.", attribute),
+		)
+		return nil
+	}
+
+	defaults := map[string]string{}
+	diagnostics.Append(value.ElementsAs(ctx, &defaults, false)...)
+
+	return defaults
 }
 
 func (p RavelProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewConfigurationResource,
+		resources.NewConfigurationVersionResource,
+		resources.NewConfigurationPromotionResource,
+		resources.NewConfigurationLabelsResource,
+		resources.NewConfigurationSetResource,
+		resources.NewSchemaResource,
+		resources.NewSecretResource,
 	}
 }
 
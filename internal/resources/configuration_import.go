@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+)
+
+const configurationImportIdFormats = "`<id>`, `<id>@<version>` or `name=<name>;scope.<key>=<value>;...`"
+
+// configurationImportId is the parsed form of a ravel_configuration import identifier.
+type configurationImportId struct {
+	Id string
+	// Version is nil when the latest version must be imported.
+	Version *int64
+	Name    string
+	Scope   models.Scope
+}
+
+func parseConfigurationImportId(importId string) (*configurationImportId, error) {
+	importId = strings.TrimSpace(importId)
+	if importId == "" {
+		return nil, fmt.Errorf("import identifier is empty, expected %s", configurationImportIdFormats)
+	}
+
+	if strings.Contains(importId, "=") {
+		return parseConfigurationLookupImportId(importId)
+	}
+
+	id, version, found := strings.Cut(importId, "@")
+	if id == "" {
+		return nil, fmt.Errorf("import identifier %q has an empty id, expected %s", importId, configurationImportIdFormats)
+	}
+
+	if !found {
+		return &configurationImportId{Id: id}, nil
+	}
+
+	parsedVersion, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || parsedVersion < 0 {
+		return nil, fmt.Errorf("import identifier %q has an invalid version %q, expected a non negative integer", importId, version)
+	}
+
+	return &configurationImportId{Id: id, Version: &parsedVersion}, nil
+}
+
+func parseConfigurationLookupImportId(importId string) (*configurationImportId, error) {
+	parsed := &configurationImportId{Scope: models.Scope{}}
+
+	for _, part := range strings.Split(importId, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		key, val, found := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if !found || key == "" || val == "" {
+			return nil, fmt.Errorf("import identifier %q has an invalid element %q, expected key=value", importId, part)
+		}
+
+		switch {
+		case key == "name":
+			parsed.Name = val
+		case strings.HasPrefix(key, "scope.") && len(key) > len("scope."):
+			parsed.Scope[strings.TrimPrefix(key, "scope.")] = val
+		default:
+			return nil, fmt.Errorf("import identifier %q has an unsupported key %q, expected name or scope.<key>", importId, key)
+		}
+	}
+
+	if parsed.Name == "" {
+		return nil, fmt.Errorf("import identifier %q is missing the configuration name, expected %s", importId, configurationImportIdFormats)
+	}
+
+	return parsed, nil
+}
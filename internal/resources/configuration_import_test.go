@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+)
+
+func TestParseConfigurationImportId(t *testing.T) {
+	version := int64(3)
+
+	testCases := map[string]struct {
+		importId string
+		expected *configurationImportId
+	}{
+		"latest": {
+			importId: "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+			expected: &configurationImportId{Id: "7eb918e0-49b6-4519-bb5a-850c42d8da04"},
+		},
+		"version": {
+			importId: "7eb918e0-49b6-4519-bb5a-850c42d8da04@3",
+			expected: &configurationImportId{Id: "7eb918e0-49b6-4519-bb5a-850c42d8da04", Version: &version},
+		},
+		"name and scope": {
+			importId: "name=smtp;scope.type=configuration;scope.fleetcommand_account=acme",
+			expected: &configurationImportId{
+				Name:  "smtp",
+				Scope: models.Scope{"type": "configuration", "fleetcommand_account": "acme"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseConfigurationImportId(testCase.importId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(parsed, testCase.expected) {
+				t.Fatalf("expected %+v, got %+v", testCase.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestParseConfigurationImportIdInvalid(t *testing.T) {
+	for _, importId := range []string{
+		"",
+		"@3",
+		"7eb918e0-49b6-4519-bb5a-850c42d8da04@latest",
+		"7eb918e0-49b6-4519-bb5a-850c42d8da04@-1",
+		"scope.type=configuration",
+		"name=smtp;owner=team",
+		"name=smtp;scope.=x",
+	} {
+		if _, err := parseConfigurationImportId(importId); err == nil {
+			t.Errorf("expected an error for import identifier %q", importId)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationLabelsResource{}
+var _ resource.ResourceWithImportState = &ConfigurationLabelsResource{}
+
+func NewConfigurationLabelsResource() resource.Resource {
+	return &ConfigurationLabelsResource{}
+}
+
+type ConfigurationLabelsResource struct {
+	client *client.RavelClient
+}
+
+type ConfigurationLabelsResourceModel struct {
+	Id              types.String            `tfsdk:"id"`
+	ConfigurationId types.String            `tfsdk:"configuration_id"`
+	Labels          map[string]types.String `tfsdk:"labels"`
+	Timeouts        timeouts.Value          `tfsdk:"timeouts"`
+}
+
+func (r *ConfigurationLabelsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_labels"
+}
+
+func (r *ConfigurationLabelsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Labels of an existing configuration. Only the declared label keys are managed, other keys are left untouched, " +
+			"and changing labels does not publish a new definition version. The `ravel_configuration` resource managing the same " +
+			"configuration keeps these keys as long as it does not declare them",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the configuration to label",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				Required:            true,
+				MarkdownDescription: "Managed configuration labels (Map<String, String>), keys removed from the map are removed from the configuration",
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ConfigurationLabelsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ConfigurationLabelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ConfigurationLabelsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.ConfigurationId
+
+	r.patch(ctx, data, nil, "create", &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationLabelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ConfigurationLabelsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	configuration, err := r.client.GetLatestConfig(ctx, data.ConfigurationId.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("configuration with id: %s no longer exists, removing its labels from state", data.ConfigurationId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", data.ConfigurationId.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not read Ravel configuration ID: %s. Error: %s ", data.ConfigurationId.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Only the managed keys are read, keys removed outside of Terraform are added back on the next apply
+	labels := make(map[string]types.String, len(data.Labels))
+	for key := range data.Labels {
+		if val, ok := configuration.Meta.Labels[key]; ok {
+			labels[key] = types.StringValue(val)
+		}
+	}
+	data.Labels = labels
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationLabelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior *ConfigurationLabelsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.patch(ctx, data, prior.Labels, "update", &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationLabelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ConfigurationLabelsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := data.Labels
+	data.Labels = nil
+
+	r.patch(ctx, data, prior, "delete", &resp.Diagnostics)
+}
+
+// patch applies the labels of data to the configuration, removing the prior keys no longer declared.
+func (r *ConfigurationLabelsResource) patch(ctx context.Context, data *ConfigurationLabelsResourceModel, prior map[string]types.String, operation string, diagnostics *diag.Diagnostics) {
+	timeout, diags := operationTimeout(ctx, data.Timeouts, operation)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	configId := data.ConfigurationId.ValueString()
+
+	_, err := r.client.UpdateConfigLabels(ctx, configId, labelsPatch(convertToStringMap(prior), convertToStringMap(data.Labels)))
+	if client.IsNotFound(err) && operation == "delete" {
+		tflog.Trace(ctx, fmt.Sprintf("configuration with id: %s was already deleted", configId))
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration ID: %s", configId), timeout)
+		return
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error Updating Ravel configuration labels",
+			fmt.Sprintf("Could not update labels of Ravel configuration ID: %s. Error: %s ", configId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated labels of configuration with id: %s", configId))
+}
+
+// ImportState imports the labels of a configuration from its `<configuration_id>:<key>,<key>...` identifier.
+func (r *ConfigurationLabelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	configId, keys, err := parseConfigurationLabelsImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ravel configuration labels import identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	// Values are read from the configuration, keys missing from it are dropped
+	labels := make(map[string]string, len(keys))
+	for _, key := range keys {
+		labels[key] = ""
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), configId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("configuration_id"), configId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("labels"), labels)...)
+}
+
+// labelsPatch returns the merge patch setting the planned labels and removing the prior keys no longer planned.
+func labelsPatch(prior, planned map[string]string) map[string]*string {
+	patch := make(map[string]*string, len(prior)+len(planned))
+
+	for key := range prior {
+		if _, ok := planned[key]; !ok {
+			patch[key] = nil
+		}
+	}
+
+	for key, val := range planned {
+		val := val
+		patch[key] = &val
+	}
+
+	return patch
+}
+
+func parseConfigurationLabelsImportId(importId string) (string, []string, error) {
+	configId, rawKeys, found := strings.Cut(strings.TrimSpace(importId), ":")
+
+	var keys []string
+	for _, key := range strings.Split(rawKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	if !found || configId == "" || len(keys) == 0 {
+		return "", nil, fmt.Errorf("import identifier %q is invalid, expected `<configuration_id>:<key>,<key>...`", importId)
+	}
+
+	sort.Strings(keys)
+
+	return configId, keys, nil
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabelsPatch(t *testing.T) {
+	patch := labelsPatch(
+		map[string]string{"minDominoVersion": "005.008.000", "minSchemaVersion": "1.0.0"},
+		map[string]string{"minDominoVersion": "005.009.000", "owner": "release"},
+	)
+
+	if len(patch) != 3 || patch["minSchemaVersion"] != nil {
+		t.Fatalf("unexpected patch: %v", patch)
+	}
+
+	for key, expected := range map[string]string{"minDominoVersion": "005.009.000", "owner": "release"} {
+		if patch[key] == nil || *patch[key] != expected {
+			t.Errorf("unexpected value for %s: %v", key, patch[key])
+		}
+	}
+}
+
+func TestParseConfigurationLabelsImportId(t *testing.T) {
+	configId, keys, err := parseConfigurationLabelsImportId("7eb918e0-49b6-4519-bb5a-850c42d8da04:minSchemaVersion, minDominoVersion")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if configId != "7eb918e0-49b6-4519-bb5a-850c42d8da04" || !reflect.DeepEqual(keys, []string{"minDominoVersion", "minSchemaVersion"}) {
+		t.Errorf("unexpected import identifier: %s %v", configId, keys)
+	}
+
+	for _, importId := range []string{"", "7eb918e0-49b6-4519-bb5a-850c42d8da04", "7eb918e0-49b6-4519-bb5a-850c42d8da04:", ":minSchemaVersion"} {
+		if _, _, err := parseConfigurationLabelsImportId(importId); err == nil {
+			t.Errorf("expected an error for %q", importId)
+		}
+	}
+}
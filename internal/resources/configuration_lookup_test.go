@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+)
+
+const lookupConfigurationId = "7eb918e0-49b6-4519-bb5a-850c42d8da04"
+
+var lookupConfigurationResponse = `{
+    "id": "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+    "created_at": 1698258912,
+    "meta": {
+        "name": "smtp",
+        "scope": {
+            "fleetcommand_account": "acme",
+            "type": "configuration"
+        },
+        "version": %s
+    },
+    "spec": {
+        "def": {
+            "port": 465
+        }
+    }
+}`
+
+// newLookupTestServer serves the version 2 of the configuration, version 3 being the latest one.
+func newLookupTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/configurations/" + lookupConfigurationId:
+			_, _ = fmt.Fprintf(w, lookupConfigurationResponse, "3")
+		case "/configurations/" + lookupConfigurationId + "/versions/2", "/configurations/" + lookupConfigurationId + "/versions/3":
+			_, _ = fmt.Fprintf(w, lookupConfigurationResponse, path.Base(r.URL.Path))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "CONFIGURATION_NOT_FOUND", "message": "configuration not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLookupConfiguration(t *testing.T) {
+	server := newLookupTestServer(t)
+	r := &ConfigurationResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+	testCases := map[string]struct {
+		id          string
+		version     int64
+		trackLatest bool
+		expected    int64
+		outOfBand   bool
+		notFound    bool
+	}{
+		"written version": {
+			id:       lookupConfigurationId,
+			version:  2,
+			expected: 2,
+		},
+		"latest version written by terraform": {
+			id:          lookupConfigurationId,
+			version:     3,
+			trackLatest: true,
+			expected:    3,
+		},
+		"newer version published outside of terraform": {
+			id:          lookupConfigurationId,
+			version:     2,
+			trackLatest: true,
+			expected:    3,
+			outOfBand:   true,
+		},
+		"missing version": {
+			id:       lookupConfigurationId,
+			version:  1,
+			notFound: true,
+		},
+		"missing configuration": {
+			id:          "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21",
+			version:     2,
+			trackLatest: true,
+			notFound:    true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configuration, outOfBand, err := r.lookupConfiguration(context.Background(), testCase.id, testCase.version, testCase.trackLatest)
+			if testCase.notFound {
+				if !client.IsNotFound(err) {
+					t.Fatalf("expected a not found error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if configuration.Meta.Version != testCase.expected {
+				t.Errorf("expected version %d, got %d", testCase.expected, configuration.Meta.Version)
+			}
+
+			if outOfBand != testCase.outOfBand {
+				t.Errorf("expected out of band %t, got %t", testCase.outOfBand, outOfBand)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationPromotionResource{}
+var _ resource.ResourceWithImportState = &ConfigurationPromotionResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationPromotionResource{}
+
+// promotedFromLabel is the target configuration label recording the promoted `<configuration_id>@<version>`.
+const promotedFromLabel = "promotedFrom"
+
+func NewConfigurationPromotionResource() resource.Resource {
+	return &ConfigurationPromotionResource{}
+}
+
+type ConfigurationPromotionResource struct {
+	client *client.RavelClient
+}
+
+type ConfigurationPromotionResourceModel struct {
+	Id                    types.String            `tfsdk:"id"`
+	SourceConfigurationId types.String            `tfsdk:"source_configuration_id"`
+	SourceVersion         types.Int64             `tfsdk:"source_version"`
+	TargetName            types.String            `tfsdk:"target_name"`
+	TargetScope           map[string]types.String `tfsdk:"target_scope"`
+	TargetVersion         types.Int64             `tfsdk:"target_version"`
+	PromotedFrom          types.String            `tfsdk:"promoted_from"`
+	Timeouts              timeouts.Value          `tfsdk:"timeouts"`
+}
+
+func (r *ConfigurationPromotionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_promotion"
+}
+
+func (r *ConfigurationPromotionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Copies a configuration version to another scope, creating or updating the target configuration " +
+			"with the same definition and schema. The promoted version is recorded in the `" + promotedFromLabel + "` label of the target. " +
+			"Destroying the promotion keeps the target configuration",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Target configuration identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_configuration_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the configuration to promote",
+			},
+			"source_version": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "Version of the source configuration to promote",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"target_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Target configuration name, the source configuration name when not set",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_scope": schema.MapAttribute{
+				Required:            true,
+				MarkdownDescription: "Target configuration scope (Map<String, String>)",
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_version": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "Version of the target configuration written by the promotion. Publishing another version " +
+					"of the target outside of Terraform plans a new promotion",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"promoted_from": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Source `<configuration_id>@<version>` recorded in the `" + promotedFromLabel + "` label of the target configuration",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans a new promotion whenever the target configuration no longer records the configured source,
+// including when versions were published to the target outside of Terraform, which Read reports as a missing label.
+func (r *ConfigurationPromotionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var sourceId types.String
+	var sourceVersion types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("source_configuration_id"), &sourceId)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("source_version"), &sourceVersion)...)
+
+	if resp.Diagnostics.HasError() || sourceId.IsUnknown() || sourceVersion.IsUnknown() {
+		return
+	}
+
+	promotedFrom := types.StringValue(configurationVersionId(sourceId.ValueString(), sourceVersion.ValueInt64()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("promoted_from"), promotedFrom)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var priorPromotedFrom types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("promoted_from"), &priorPromotedFrom)...)
+
+	if !resp.Diagnostics.HasError() && !priorPromotedFrom.Equal(promotedFrom) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("target_version"), types.Int64Unknown())...)
+	}
+}
+
+func (r *ConfigurationPromotionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ConfigurationPromotionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &resp.State, "create")
+}
+
+func (r *ConfigurationPromotionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &resp.State, "update")
+}
+
+// Upsert promotes the source version to the target configuration, creating the target when it does not exist.
+func (r *ConfigurationPromotionResource) Upsert(ctx context.Context, diagnostics *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State, operation string) {
+	var data *ConfigurationPromotionResourceModel
+
+	diagnostics.Append(plan.Get(ctx, &data)...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, operation)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sourceId := data.SourceConfigurationId.ValueString()
+	sourceVersion := data.SourceVersion.ValueInt64()
+
+	source, err := r.client.GetConfigVersion(ctx, sourceId, int(sourceVersion))
+	if isTimeout(ctx, err) {
+		addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration ID: %s", sourceId), timeout)
+		return
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not read Ravel configuration ID: %s and version: %d. Error: %s ", sourceId, sourceVersion, err.Error()),
+		)
+		return
+	}
+
+	if data.TargetName.IsUnknown() || data.TargetName.IsNull() {
+		data.TargetName = types.StringValue(source.Meta.Name)
+	}
+
+	promotedFrom := configurationVersionId(sourceId, sourceVersion)
+
+	target, err := r.promote(ctx, source, promotedFrom, data.TargetName.ValueString(), convertToStringMap(data.TargetScope))
+	if isTimeout(ctx, err) {
+		addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration name: %s and scope: %v", data.TargetName.ValueString(), convertToStringMap(data.TargetScope)), timeout)
+		return
+	}
+
+	var conflictErr *client.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		diagnostics.AddError(
+			"Ravel configuration changed concurrently",
+			fmt.Sprintf("Ravel configuration ID: %s was updated while being promoted to, plan again. Error: %s", conflictErr.ConfigId, err.Error()),
+		)
+		return
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error Promoting Ravel configuration",
+			fmt.Sprintf("Could not promote Ravel configuration ID: %s and version: %d to name: %s and scope: %v. Error: %s ",
+				sourceId, sourceVersion, data.TargetName.ValueString(), convertToStringMap(data.TargetScope), err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(target.Id)
+	data.TargetVersion = types.Int64Value(target.Meta.Version)
+	data.PromotedFrom = types.StringValue(promotedFrom)
+
+	tflog.Trace(ctx, fmt.Sprintf("promoted configuration with id: %s and version: %d to configuration with id: %s and version: %d",
+		sourceId, sourceVersion, target.Id, target.Meta.Version))
+
+	diagnostics.Append(state.Set(ctx, &data)...)
+}
+
+// promote copies the source definition, schema and labels to the configuration with the target name and scope.
+// Nothing is published when the target already holds the promoted version.
+func (r *ConfigurationPromotionResource) promote(ctx context.Context, source *models.RavelConfig, promotedFrom string, name string, scope models.Scope) (*models.RavelConfig, error) {
+	labels := maps.Clone(source.Meta.Labels)
+	if labels == nil {
+		labels = models.Labels{}
+	}
+	labels[promotedFromLabel] = promotedFrom
+
+	meta := models.RavelConfigMeta{
+		RavelResourceMeta: models.RavelResourceMeta{
+			Name:   name,
+			Scope:  scope,
+			Labels: labels,
+		},
+	}
+
+	candidates, err := r.client.FindConfigs(ctx, name, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lookups match configurations whose scope includes the target scope, only the exact scope is the target
+	var target *models.RavelConfig
+	for i := range candidates {
+		if maps.Equal(candidates[i].Meta.Scope, scope) {
+			target = &candidates[i]
+			break
+		}
+	}
+
+	if target == nil {
+		return r.client.CreateConfig(ctx, meta, source.Spec.ConfigurationFormat, source.Spec.Def)
+	}
+
+	if maps.Equal(target.Meta.Labels, labels) &&
+		reflect.DeepEqual(target.Spec.ConfigurationFormat, source.Spec.ConfigurationFormat) &&
+		reflect.DeepEqual(target.Spec.Def, source.Spec.Def) {
+		tflog.Debug(ctx, fmt.Sprintf("configuration with id: %s already holds the promoted version", target.Id))
+		return target, nil
+	}
+
+	return r.client.UpdateConfig(ctx, target.Id, target.Meta.Version, meta, source.Spec.ConfigurationFormat, source.Spec.Def)
+}
+
+func (r *ConfigurationPromotionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ConfigurationPromotionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	target, err := r.client.GetLatestConfig(ctx, data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("configuration with id: %s no longer exists, removing the promotion from state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not read Ravel configuration ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.TargetName = types.StringValue(target.Meta.Name)
+	data.TargetScope = copyAndConvertMap(target.Meta.Scope)
+
+	// The version written by the promotion is kept, it is only read from the target on import
+	publishedOutOfBand := !data.TargetVersion.IsNull() && data.TargetVersion.ValueInt64() != target.Meta.Version
+	if data.TargetVersion.IsNull() {
+		data.TargetVersion = types.Int64Value(target.Meta.Version)
+	}
+
+	// A missing label, or a version published outside of Terraform even when it kept the label, means the target
+	// no longer holds the promoted version, the next apply promotes again
+	data.PromotedFrom = types.StringNull()
+	if promotedFrom, ok := target.Meta.Labels[promotedFromLabel]; ok && !publishedOutOfBand {
+		data.PromotedFrom = types.StringValue(promotedFrom)
+	}
+
+	// The source is only read from the label on import
+	if data.SourceConfigurationId.IsNull() && !data.PromotedFrom.IsNull() {
+		promotion, err := parseConfigurationImportId(data.PromotedFrom.ValueString())
+		if err != nil || promotion.Version == nil {
+			resp.Diagnostics.AddError(
+				"Invalid Ravel configuration promotion",
+				fmt.Sprintf("Ravel configuration ID: %s has an invalid %s label %q, expected `<configuration_id>@<version>`", data.Id.ValueString(), promotedFromLabel, data.PromotedFrom.ValueString()),
+			)
+			return
+		}
+
+		data.SourceConfigurationId = types.StringValue(promotion.Id)
+		data.SourceVersion = types.Int64Value(*promotion.Version)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the promotion from the state, the target configuration is kept.
+func (r *ConfigurationPromotionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ConfigurationPromotionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("configuration with id: %s promoted from: %s is kept", data.Id.ValueString(), data.PromotedFrom.ValueString()))
+}
+
+// ImportState imports the promotion recorded on the target configuration with the given id.
+func (r *ConfigurationPromotionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,248 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const promotionSourceId = "7eb918e0-49b6-4519-bb5a-850c42d8da04"
+
+var promotionSourceResponse = `{
+    "id": "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+    "meta": {
+        "name": "smtp",
+        "scope": {"environment": "staging"},
+        "version": 2,
+        "labels": {"team": "platform"}
+    },
+    "spec": {
+        "def": {"port": 465}
+    }
+}`
+
+// promotionTestServer serves version 2 of the source configuration and records the configurations written to the
+// target scope, the existing target being published to concurrently when conflict is set.
+type promotionTestServer struct {
+	target   *models.RavelConfig
+	conflict bool
+	written  []models.RavelConfig
+}
+
+func (s *promotionTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/configurations/"+promotionSourceId+"/versions/2":
+		_, _ = w.Write([]byte(promotionSourceResponse))
+	case r.Method == http.MethodGet && s.target != nil && r.URL.Path == "/configurations/"+s.target.Id:
+		_ = json.NewEncoder(w).Encode(s.target)
+	case r.Method == http.MethodGet && r.URL.Path == "/configurations":
+		var found []models.RavelConfig
+		if s.target != nil && r.URL.Query().Get("name") == s.target.Meta.Name {
+			found = append(found, *s.target)
+		}
+		_ = json.NewEncoder(w).Encode(found)
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		if s.conflict {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"code": "VERSION_CONFLICT", "message": "latest version is 8"}`))
+			return
+		}
+
+		var written models.RavelConfig
+		_ = json.NewDecoder(r.Body).Decode(&written)
+		written.Id = "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21"
+		if s.target != nil {
+			written.Meta.Version = s.target.Meta.Version + 1
+		}
+		s.written = append(s.written, written)
+		_ = json.NewEncoder(w).Encode(written)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": "CONFIGURATION_NOT_FOUND", "message": "configuration not found"}`))
+	}
+}
+
+// createPromotion runs the creation of a promotion of the given source version to the production scope.
+func createPromotion(t *testing.T, server *promotionTestServer, sourceVersion int64) *resource.CreateResponse {
+	t.Helper()
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	ctx := context.Background()
+	r := &ConfigurationPromotionResource{client: client.New(ravelhttp.New(httpServer.URL, "test", "token"))}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: null}}
+	diags := req.Plan.SetAttribute(ctx, path.Root("source_configuration_id"), promotionSourceId)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("source_version"), sourceVersion)...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("target_scope"), map[string]string{"environment": "production"})...)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	r.Create(ctx, req, resp)
+
+	return resp
+}
+
+func TestPromoteCreatesTarget(t *testing.T) {
+	server := &promotionTestServer{}
+	resp := createPromotion(t, server, 2)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	if len(server.written) != 1 {
+		t.Fatalf("expected one configuration to be written, got %d", len(server.written))
+	}
+
+	written := server.written[0]
+	if written.Meta.Name != "smtp" || written.Meta.Scope["environment"] != "production" ||
+		written.Meta.Labels["team"] != "platform" || written.Meta.Labels[promotedFromLabel] != promotionSourceId+"@2" ||
+		written.Spec.Def["port"] != float64(465) {
+		t.Errorf("unexpected promoted configuration: %+v", written)
+	}
+
+	var data ConfigurationPromotionResourceModel
+	if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Id.ValueString() != written.Id || data.TargetName.ValueString() != "smtp" || data.PromotedFrom.ValueString() != promotionSourceId+"@2" {
+		t.Errorf("unexpected state: %+v", data)
+	}
+}
+
+func TestPromoteMissingSourceVersion(t *testing.T) {
+	server := &promotionTestServer{}
+	resp := createPromotion(t, server, 3)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Error Reading Ravel configuration" {
+		t.Fatalf("expected a read error, got: %v", resp.Diagnostics)
+	}
+
+	if len(server.written) != 0 {
+		t.Errorf("expected nothing to be written, got: %+v", server.written)
+	}
+}
+
+func TestPromoteTargetConflict(t *testing.T) {
+	server := &promotionTestServer{
+		target: &models.RavelConfig{
+			Id: "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21",
+			Meta: models.RavelConfigMeta{
+				RavelResourceMeta: models.RavelResourceMeta{Name: "smtp", Scope: models.Scope{"environment": "production"}},
+				Version:           7,
+			},
+			Spec: models.RavelConfigSpec{Def: map[string]any{"port": float64(587)}},
+		},
+		conflict: true,
+	}
+	resp := createPromotion(t, server, 2)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+
+	if diagnostic := resp.Diagnostics.Errors()[0]; diagnostic.Summary() != "Ravel configuration changed concurrently" ||
+		!strings.Contains(diagnostic.Detail(), "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21") {
+		t.Errorf("unexpected conflict error: %s: %s", diagnostic.Summary(), diagnostic.Detail())
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected no state to be saved")
+	}
+}
+
+func TestReadPromotionTargetPublishedOutOfBand(t *testing.T) {
+	tests := []struct {
+		name          string
+		latestVersion int64
+		promotedFrom  types.String
+	}{
+		{
+			name:          "unchanged",
+			latestVersion: 3,
+			promotedFrom:  types.StringValue(promotionSourceId + "@2"),
+		},
+		{
+			name:          "published outside of Terraform",
+			latestVersion: 4,
+			promotedFrom:  types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The version published outside of Terraform keeps the promotion label
+			server := &promotionTestServer{
+				target: &models.RavelConfig{
+					Id: "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21",
+					Meta: models.RavelConfigMeta{
+						RavelResourceMeta: models.RavelResourceMeta{
+							Name:   "smtp",
+							Scope:  models.Scope{"environment": "production"},
+							Labels: models.Labels{promotedFromLabel: promotionSourceId + "@2"},
+						},
+						Version: tt.latestVersion,
+					},
+				},
+			}
+			httpServer := httptest.NewServer(server)
+			t.Cleanup(httpServer.Close)
+
+			ctx := context.Background()
+			r := &ConfigurationPromotionResource{client: client.New(ravelhttp.New(httpServer.URL, "test", "token"))}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+			diags := req.State.SetAttribute(ctx, path.Root("id"), server.target.Id)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("source_configuration_id"), promotionSourceId)...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("source_version"), int64(2))...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("target_version"), int64(3))...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("promoted_from"), promotionSourceId+"@2")...)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			resp := &resource.ReadResponse{State: req.State}
+			r.Read(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var data ConfigurationPromotionResourceModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if !data.PromotedFrom.Equal(tt.promotedFrom) || data.TargetVersion.ValueInt64() != 3 {
+				t.Errorf("unexpected state: %+v", data)
+			}
+		})
+	}
+}
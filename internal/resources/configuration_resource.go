@@ -6,17 +6,30 @@ package resources
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
 
 	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
 	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -25,13 +38,18 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ConfigurationResource{}
 var _ resource.ResourceWithImportState = &ConfigurationResource{}
+var _ resource.ResourceWithConfigValidators = &ConfigurationResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationResource{}
+var _ resource.ResourceWithUpgradeState = &ConfigurationResource{}
 
 func NewConfigurationResource() resource.Resource {
 	return &ConfigurationResource{}
 }
 
 type ConfigurationResource struct {
-	client *client.RavelClient
+	client        *client.RavelClient
+	defaultLabels models.Labels
+	defaultScope  models.Scope
 }
 
 type ConfigurationSchemaModel struct {
@@ -40,14 +58,61 @@ type ConfigurationSchemaModel struct {
 	Scope   map[string]types.String `tfsdk:"scope"`
 }
 
+// meta returns the configuration format referenced by the schema, nil when no schema is set.
+func (m *ConfigurationSchemaModel) meta() *models.RavelSchemaMeta {
+	if m == nil {
+		return nil
+	}
+
+	return &models.RavelSchemaMeta{
+		RavelResourceMeta: models.RavelResourceMeta{
+			Name:  m.Name.ValueString(),
+			Scope: convertToStringMap(m.Scope),
+		},
+		Version: m.Version.ValueString(),
+	}
+}
+
+// configurationSchemaFrom returns the schema model of a configuration format, nil when format is nil.
+func configurationSchemaFrom(format *models.RavelSchemaMeta) *ConfigurationSchemaModel {
+	if format == nil {
+		return nil
+	}
+
+	return &ConfigurationSchemaModel{
+		Name:    types.StringValue(format.Name),
+		Version: types.StringValue(format.Version),
+		Scope:   copyAndConvertMap(format.Scope),
+	}
+}
+
 type ConfigurationResourceModel struct {
-	Id         types.String              `tfsdk:"id"`
-	Version    types.Int64               `tfsdk:"version"`
-	Name       types.String              `tfsdk:"name"`
-	Labels     map[string]types.String   `tfsdk:"labels"`
-	Scope      map[string]types.String   `tfsdk:"scope"`
-	Schema     *ConfigurationSchemaModel `tfsdk:"schema"`
-	Definition types.String              `tfsdk:"definition"`
+	Id                types.String              `tfsdk:"id"`
+	Version           types.Int64               `tfsdk:"version"`
+	Name              types.String              `tfsdk:"name"`
+	Labels            map[string]types.String   `tfsdk:"labels"`
+	Scope             map[string]types.String   `tfsdk:"scope"`
+	Schema            *ConfigurationSchemaModel `tfsdk:"schema"`
+	Definition        customtypes.JSON          `tfsdk:"definition"`
+	DefinitionYAML    customtypes.Document      `tfsdk:"definition_yaml"`
+	DefinitionTOML    customtypes.Document      `tfsdk:"definition_toml"`
+	DefinitionJSONC   customtypes.Document      `tfsdk:"definition_jsonc"`
+	Settings          types.Dynamic             `tfsdk:"settings"`
+	DefinitionFile    types.String              `tfsdk:"definition_file"`
+	DefinitionSHA256  types.String              `tfsdk:"definition_sha256"`
+	BaseConfiguration types.String              `tfsdk:"base_configuration_id"`
+	BasePinnedVersion types.Int64               `tfsdk:"base_configuration_version"`
+	BaseVersion       types.Int64               `tfsdk:"base_version"`
+	Overrides         customtypes.JSON          `tfsdk:"overrides"`
+	EffectiveDef      customtypes.JSON          `tfsdk:"effective_definition"`
+	DefinitionHash    types.String              `tfsdk:"definition_hash"`
+	SensitivePaths    []types.String            `tfsdk:"sensitive_paths"`
+	RedactedDef       customtypes.JSON          `tfsdk:"redacted_definition"`
+	SecretReferences  types.Map                 `tfsdk:"secret_references"`
+	SecretsHash       types.String              `tfsdk:"secrets_hash"`
+	TrackLatest       types.Bool                `tfsdk:"track_latest"`
+	RollbackToVersion types.Int64               `tfsdk:"rollback_to_version"`
+	Timeouts          timeouts.Value            `tfsdk:"timeouts"`
 }
 
 func (r *ConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,6 +123,7 @@ func (r *ConfigurationResource) Schema(ctx context.Context, req resource.SchemaR
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example resource",
+		Version:             configurationSchemaVersion,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -80,18 +146,17 @@ func (r *ConfigurationResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"labels": schema.MapAttribute{
-				MarkdownDescription: "Configuration labels (Map<String, String>)",
-				ElementType:         types.StringType,
-				Optional:            true,
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				MarkdownDescription: "Configuration labels (Map<String, String>), merged over the provider `default_labels`. " +
+					"Only these keys are managed, label keys set outside of Terraform, such as by `ravel_configuration_labels`, are kept",
 			},
 			"scope": schema.MapAttribute{
-				MarkdownDescription: "Configuration scope (Map<String, String>)",
+				MarkdownDescription: "Configuration scope (Map<String, String>), merged over the provider `default_scope`",
 				ElementType:         types.StringType,
 				Optional:            true,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.UseStateForUnknown(),
-					mapplanmodifier.RequiresReplace(),
-				},
+				Computed:            true,
 			},
 			"schema": schema.SingleNestedAttribute{
 				Optional: true,
@@ -115,45 +180,737 @@ func (r *ConfigurationResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"definition": schema.StringAttribute{
-				Required:            true,
-				Sensitive:           true,
-				MarkdownDescription: "Configuration definition",
+				CustomType: customtypes.JSONType{},
+				Optional:   true,
+				Computed:   true,
+				Sensitive:  true,
+				MarkdownDescription: "Configuration definition (JSON). Terraform still plans an update when only whitespace or key ordering " +
+					"changed, but applying it keeps the current version instead of publishing a new one. Use `secret://` references for secret values to keep their plaintext out of the Terraform state",
+				Validators: []validator.String{
+					validators.SensitiveJSONObject(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"definition_yaml":  definitionFormatAttribute(formats.YAML, "YAML"),
+			"definition_toml":  definitionFormatAttribute(formats.TOML, "TOML"),
+			"definition_jsonc": definitionFormatAttribute(formats.JSONC, "JSON with comments"),
+			"settings": schema.DynamicAttribute{
+				Optional: true,
+				MarkdownDescription: "Configuration definition as an object, converted to JSON before being published. " +
+					"Unlike `definition`, plans show field-level differences, so use `secret://` references for secret values",
+			},
+			"definition_file": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Path of a local file holding the configuration definition, read at plan time. " +
+					"Files ending in `.yaml` or `.yml` are read as YAML, `.toml` as TOML, `.jsonc` as JSON with comments and any other file as JSON",
+			},
+			"definition_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the content of `definition_file` last published",
+			},
+			"base_configuration_id": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Identifier of the configuration this configuration is layered on. " +
+					"Its definition, patched with `overrides`, is published as `definition`, and published again whenever a new base version is used",
+			},
+			"base_configuration_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the base configuration to layer on, the latest version when not set",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.AlsoRequires(path.MatchRoot("base_configuration_id")),
+				},
+			},
+			"base_version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the base configuration the published definition was merged from",
+			},
+			"overrides": schema.StringAttribute{
+				CustomType:          customtypes.JSONType{},
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "JSON merge patch (RFC 7396) applied to the definition of the base configuration",
+				Validators: []validator.String{
+					validators.SensitiveJSONObject(),
+					stringvalidator.AlsoRequires(path.MatchRoot("base_configuration_id")),
+				},
+			},
+			"effective_definition": schema.StringAttribute{
+				CustomType:          customtypes.JSONType{},
+				Computed:            true,
+				MarkdownDescription: "Definition stored by Ravel (JSON), with defaults filled in and plaintext secrets masked",
+			},
+			"definition_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the canonical JSON encoding of the definition stored by Ravel",
+			},
+			"sensitive_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "Dotted JSON paths of the definition fields holding secrets, such as `email_notifications.authentication.password`. " +
+					"Their values are masked in `redacted_definition`, `effective_definition` and plan diagnostics, string values are also redacted from logs and errors",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validators.JSONPath()),
+				},
+			},
+			"redacted_definition": schema.StringAttribute{
+				CustomType:          customtypes.JSONType{},
+				Computed:            true,
+				MarkdownDescription: "Definition (JSON) with the fields listed in `sensitive_paths` masked, showing the other changes in plans. Null when `sensitive_paths` is not set",
+			},
+			"secret_references": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Secret references (`secret://...`) stored by Ravel in place of plaintext values, by JSON path",
+			},
+			"secrets_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "HMAC-SHA256 of the plaintext secrets last published, keyed with the configuration identifier, used to detect secret drift",
+			},
+			"track_latest": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Read the latest version of the configuration instead of the version last written by Terraform, reporting versions published outside of Terraform as drift to be overwritten on the next apply",
+			},
+			"rollback_to_version": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Publish the definition of this historical version as the new version of the configuration. " +
+					"Conflicts with `definition`, the restored definition is kept in state until `definition` is set again",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
 
-func (r *ConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
+func (r *ConfigurationResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("definition"),
+			path.MatchRoot("definition_yaml"),
+			path.MatchRoot("definition_toml"),
+			path.MatchRoot("definition_jsonc"),
+			path.MatchRoot("settings"),
+			path.MatchRoot("definition_file"),
+			path.MatchRoot("base_configuration_id"),
+			path.MatchRoot("rollback_to_version"),
+		),
+	}
+}
+
+func (r *ConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	ctx = definitionSensitiveValues(ctx, req.Plan, req.State).maskLogs(ctx)
+
+	r.planDefaults(ctx, req, resp)
+	r.planRollback(ctx, req, resp)
+	r.planDefinitionFormats(ctx, req, resp)
+	r.planSettings(ctx, req, resp)
+	r.planDefinitionFile(ctx, req, resp)
+	r.planBaseConfiguration(ctx, req, resp)
+	r.validateDefinitionSchema(ctx, resp)
+	r.planRedactedDefinition(ctx, req, resp)
+	r.planEffectiveDefinition(ctx, req, resp)
+	r.planSecretsHash(ctx, req, resp)
+	r.summarizeDefinitionChanges(ctx, req, resp)
+
+	resp.Diagnostics = definitionSensitiveValues(ctx, resp.Plan, req.State).redactDiagnostics(resp.Diagnostics)
+}
+
+// planDefaults plans the labels and scope merged over the provider defaults.
+func (r *ConfigurationResource) planDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	for attribute, defaults := range map[string]map[string]string{"labels": r.defaultLabels, "scope": r.defaultScope} {
+		var configured types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &configured)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		merged, diags := mergeDefaults(defaults, configured)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), merged)...)
+	}
+
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// Changing the scope, including an inherited key, moves the configuration
+	var planned, prior types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("scope"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("scope"), &prior)...)
+
+	if !planned.IsUnknown() && !planned.Equal(prior) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("scope"))
+	}
+}
+
+// planRollback replaces the planned definition with the one of the version to roll back to.
+func (r *ConfigurationResource) planRollback(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var rollbackTo types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rollback_to_version"), &rollbackTo)...)
+
+	if resp.Diagnostics.HasError() || rollbackTo.IsNull() || rollbackTo.IsUnknown() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rollback_to_version"),
+			"Invalid Ravel configuration rollback",
+			"A configuration can only be rolled back once it exists, set definition to create it.",
+		)
+		return
+	}
+
+	var priorRollbackTo types.Int64
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rollback_to_version"), &priorRollbackTo)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+
+	// The rollback was already applied, the restored definition is kept in state
+	if resp.Diagnostics.HasError() || priorRollbackTo.Equal(rollbackTo) {
+		return
+	}
+
+	// The version cannot be read before the provider is configured, the restored definition is known at apply
+	if r.client == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONUnknown())...)
+		return
+	}
+
+	timeout, diags := planTimeout(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	configuration, err := r.client.GetConfigVersion(ctx, id.ValueString(), int(rollbackTo.ValueInt64()))
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", id.ValueString()), timeout)
+		return
+	}
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rollback_to_version"),
+			"Invalid Ravel configuration rollback",
+			fmt.Sprintf("Ravel configuration ID: %s has no version %d.", id.ValueString(), rollbackTo.ValueInt64()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not read Ravel configuration ID: %s and version: %d. Error: %s ", id.ValueString(), rollbackTo.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	definition, err := json.Marshal(configuration.Spec.Def)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not encode definition of Ravel configuration ID: %s and version: %d. Error: %s ", id.ValueString(), rollbackTo.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(string(definition)))...)
+	resp.Diagnostics.AddWarning(
+		"Ravel configuration rollback",
+		fmt.Sprintf("Ravel configuration ID: %s will be rolled back, the definition of version %d will be published as a new version.", id.ValueString(), rollbackTo.ValueInt64()),
+	)
+}
+
+// planDefinitionFormats converts the alternative definition input in use into the planned JSON definition.
+func (r *ConfigurationResource) planDefinitionFormats(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attribute := range definitionFormatAttributes {
+		var document customtypes.Document
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(attribute), &document)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if document.IsNull() {
+			continue
+		}
+
+		if document.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONUnknown())...)
+			return
+		}
+
+		// Invalid documents are reported by the attribute validators
+		decoded, err := formats.Decode(document.Format, document.ValueString())
+		if err != nil {
+			return
+		}
+
+		setPlannedDefinition(ctx, req, resp, decoded)
+		return
+	}
+}
+
+// planSettings converts the `settings` object into the planned JSON definition.
+func (r *ConfigurationResource) planSettings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var settings types.Dynamic
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("settings"), &settings)...)
+
+	if resp.Diagnostics.HasError() || settings.IsNull() {
+		return
+	}
+
+	decoded, known, err := settingsToDefinition(ctx, settings)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("settings"),
+			"Invalid configuration settings",
+			err.Error(),
+		)
+		return
+	}
+
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONUnknown())...)
+		return
+	}
+
+	setPlannedDefinition(ctx, req, resp, decoded)
+}
+
+// planDefinitionFile reads `definition_file` into the planned definition, recording the hash of the file.
+func (r *ConfigurationResource) planDefinitionFile(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filename types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition_file"), &filename)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if filename.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), types.StringNull())...)
+		return
+	}
+
+	if filename.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), types.StringUnknown())...)
+		return
+	}
+
+	definition, hash, err := readDefinitionFile(filename.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition_file"),
+			"Unable to read Ravel configuration definition file",
+			fmt.Sprintf("Unable to read definition file %s: %s", filename.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	setPlannedDefinition(ctx, req, resp, definition)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_sha256"), types.StringValue(hash))...)
+}
+
+// planBaseConfiguration plans the definition of the base configuration patched with `overrides`.
+func (r *ConfigurationResource) planBaseConfiguration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var baseId types.String
+	var pinnedVersion types.Int64
+	var overrides customtypes.JSON
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("base_configuration_id"), &baseId)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("base_configuration_version"), &pinnedVersion)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("overrides"), &overrides)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if baseId.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_version"), types.Int64Null())...)
+		return
+	}
+
+	if baseId.IsUnknown() || pinnedVersion.IsUnknown() || overrides.IsUnknown() || r.client == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_version"), types.Int64Unknown())...)
+		return
+	}
+
+	timeout, diags := planTimeout(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.RavelClient)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if !ok {
+	var base *models.RavelConfig
+	var err error
+	if pinnedVersion.IsNull() {
+		base, err = r.client.GetLatestConfig(ctx, baseId.ValueString())
+	} else {
+		base, err = r.client.GetConfigVersion(ctx, baseId.ValueString(), int(pinnedVersion.ValueInt64()))
+	}
+
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("base Ravel configuration ID: %s", baseId.ValueString()), timeout)
+		return
+	}
+	if client.IsNotFound(err) && pinnedVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_configuration_id"),
+			"Invalid Ravel base configuration",
+			fmt.Sprintf("Ravel configuration ID: %s does not exist.", baseId.ValueString()),
+		)
+		return
+	}
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_configuration_version"),
+			"Invalid Ravel base configuration",
+			fmt.Sprintf("Ravel configuration ID: %s has no version %d.", baseId.ValueString(), pinnedVersion.ValueInt64()),
+		)
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.RavelClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not read base Ravel configuration ID: %s. Error: %s ", baseId.ValueString(), err.Error()),
 		)
+		return
+	}
+
+	definition := base.Spec.Def
+	if !overrides.IsNull() {
+		var patch map[string]any
+		// Invalid overrides are reported by the attribute validators
+		if err := json.Unmarshal([]byte(overrides.ValueString()), &patch); err != nil {
+			return
+		}
 
+		definition = mergePatch(definition, patch)
+	}
+
+	setPlannedDefinition(ctx, req, resp, definition)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_version"), types.Int64Value(base.Meta.Version))...)
+}
+
+// planRedactedDefinition plans the definition with the fields listed in `sensitive_paths` masked.
+func (r *ConfigurationResource) planRedactedDefinition(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var paths types.List
+	var definition customtypes.JSON
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("sensitive_paths"), &paths)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition"), &definition)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if paths.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("redacted_definition"), customtypes.NewJSONNull())...)
+		return
+	}
+
+	if paths.IsUnknown() || definition.IsUnknown() || hasUnknownElement(paths) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("redacted_definition"), customtypes.NewJSONUnknown())...)
+		return
+	}
+
+	var sensitivePaths []string
+	resp.Diagnostics.Append(paths.ElementsAs(ctx, &sensitivePaths, false)...)
+
+	redacted, err := redactedDefinition(definition.ValueString(), sensitivePaths)
+	if err != nil {
 		return
 	}
 
-	r.client = client
+	if !req.State.Raw.IsNull() {
+		var prior customtypes.JSON
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("redacted_definition"), &prior)...)
+
+		if equal, err := customtypes.SemanticallyEqualJSON(prior.ValueString(), redacted.ValueString()); err == nil && equal {
+			redacted = prior
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("redacted_definition"), redacted)...)
+}
+
+// planEffectiveDefinition keeps the definition stored by Ravel in the plan while the definition does not change.
+func (r *ConfigurationResource) planEffectiveDefinition(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior customtypes.JSON
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("definition"), &prior)...)
+
+	if resp.Diagnostics.HasError() || planned.IsUnknown() || !planned.Equal(prior) {
+		return
+	}
+
+	var effective customtypes.JSON
+	var hash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("effective_definition"), &effective)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("definition_hash"), &hash)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_definition"), effective)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_hash"), hash)...)
+}
+
+// planSecretsHash plans the hash of the configured secret values, a hash differing from the one last read means the
+// secrets changed outside of Terraform, or in the configuration, and a new version is published.
+func (r *ConfigurationResource) planSecretsHash(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var id, prior types.String
+	var priorReferences types.Map
+	var planned customtypes.JSON
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("secrets_hash"), &prior)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("secret_references"), &priorReferences)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition"), &planned)...)
+
+	if resp.Diagnostics.HasError() || prior.IsNull() || priorReferences.IsNull() || planned.IsUnknown() || planned.IsNull() {
+		return
+	}
+
+	references := map[string]string{}
+	resp.Diagnostics.Append(priorReferences.ElementsAs(ctx, &references, false)...)
+
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(planned.ValueString()), &definition); err != nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	if secretsHash(id.ValueString(), references, definition) == prior.ValueString() {
+		return
+	}
+
+	// Publishing the secrets again may store new references and changes the attributes computed from them
+	for _, attribute := range []string{"secrets_hash", "definition_hash"} {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), types.StringUnknown())...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_references"), types.MapUnknown(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_definition"), customtypes.NewJSONUnknown())...)
+}
+
+// summarizeDefinitionChanges reports the field-level changes of the sensitive definition as a warning.
+func (r *ConfigurationResource) summarizeDefinitionChanges(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var id types.String
+	var planned, prior customtypes.JSON
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("definition"), &prior)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition"), &planned)...)
+
+	if resp.Diagnostics.HasError() || planned.IsUnknown() || planned.IsNull() || prior.IsNull() || planned.Equal(prior) {
+		return
+	}
+
+	var priorDefinition, plannedDefinition map[string]any
+	if json.Unmarshal([]byte(prior.ValueString()), &priorDefinition) != nil || json.Unmarshal([]byte(planned.ValueString()), &plannedDefinition) != nil {
+		return
+	}
+
+	changes := maskSensitiveChanges(diffDefinitions(priorDefinition, plannedDefinition), plannedSensitivePaths(ctx, resp.Plan))
+	if len(changes) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("definition"),
+		"Ravel configuration definition changes",
+		fmt.Sprintf("Ravel configuration ID: %s definition will change (values of secret fields are masked):\n%s", id.ValueString(), renderDefinitionChanges(changes)),
+	)
+}
+
+// setPlannedDefinition plans definition as the JSON definition, keeping the prior state value when semantically equal.
+func setPlannedDefinition(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, definition map[string]any) {
+	encoded, err := json.Marshal(definition)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid configuration definition", err.Error())
+		return
+	}
+
+	planned := customtypes.NewJSONValue(string(encoded))
+
+	if !req.State.Raw.IsNull() {
+		var prior customtypes.JSON
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("definition"), &prior)...)
+
+		if equal, err := customtypes.SemanticallyEqualJSON(prior.ValueString(), planned.ValueString()); err == nil && equal {
+			planned = prior
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), planned)...)
+}
+
+// validateDefinitionSchema validates the planned definition against the configuration format it references.
+func (r *ConfigurationResource) validateDefinitionSchema(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	var definition customtypes.JSON
+	var configurationFormat *ConfigurationSchemaModel
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("definition"), &definition)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("schema"), &configurationFormat)...)
+
+	if resp.Diagnostics.HasError() || configurationFormat == nil || definition.IsNull() || definition.IsUnknown() ||
+		configurationFormat.Name.IsUnknown() || configurationFormat.Version.IsUnknown() {
+		return
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(definition.ValueString()), &decoded); err != nil {
+		return
+	}
+
+	meta := models.RavelSchemaMeta{
+		RavelResourceMeta: models.RavelResourceMeta{
+			Name:  configurationFormat.Name.ValueString(),
+			Scope: convertToStringMap(configurationFormat.Scope),
+		},
+		Version: configurationFormat.Version.ValueString(),
+	}
+
+	timeout, diags := planTimeout(ctx, resp.Plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	schemas, err := r.client.FindSchemas(ctx, meta)
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration format name: %s and version: %s", meta.Name, meta.Version), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration format",
+			fmt.Sprintf("Could not read Ravel configuration format name: %s and version: %s. Error: %s ", meta.Name, meta.Version, err.Error()),
+		)
+		return
+	}
+
+	// The schema may be published by the same apply, it is then validated by Ravel itself.
+	if len(schemas) == 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("schema"),
+			"Ravel configuration format not found",
+			fmt.Sprintf("Ravel configuration format name: %s and version: %s does not exist yet, the definition will only be validated on apply.", meta.Name, meta.Version),
+		)
+		return
+	}
+
+	violations, err := validateDefinition(schemas[0].Spec.Def, decoded)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("schema"),
+			"Invalid Ravel configuration format",
+			fmt.Sprintf("Ravel configuration format name: %s and version: %s could not be compiled, the definition will only be validated on apply. Error: %s", meta.Name, meta.Version, err.Error()),
+		)
+		return
+	}
+
+	sensitivePaths := plannedSensitivePaths(ctx, resp.Plan)
+	for _, violation := range violations {
+		field := violation.Path
+		if field == "" {
+			field = "(root)"
+		}
+
+		// Messages may quote the offending value
+		if underSensitivePath(violation.Path, sensitivePaths) {
+			violation.Message = "the value does not match the configuration format"
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Invalid Ravel configuration definition",
+			fmt.Sprintf("Field %s does not match configuration format name: %s and version: %s: %s", field, meta.Name, meta.Version, violation.Message),
+		)
+	}
+}
+
+func (r *ConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+	r.defaultLabels = providerData.DefaultLabels
+	r.defaultScope = providerData.DefaultScope
 }
 
 func (r *ConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, nil, &resp.State)
 }
 
 func (r *ConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &req.State, &resp.State)
 }
 
-func (r *ConfigurationResource) Upsert(ctx context.Context, diagnostics *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State) {
+// Upsert creates the configuration when priorState is nil, otherwise it publishes a new version of the
+// existing configuration, expecting its latest version to still be the one in priorState.
+func (r *ConfigurationResource) Upsert(ctx context.Context, diagnostics *diag.Diagnostics, plan *tfsdk.Plan, priorState *tfsdk.State, state *tfsdk.State) {
 	var data *ConfigurationResourceModel
 
 	// Read Terraform plan data into the model
@@ -163,6 +920,31 @@ func (r *ConfigurationResource) Upsert(ctx context.Context, diagnostics *diag.Di
 		return
 	}
 
+	operation := "create"
+	if priorState != nil {
+		operation = "update"
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, operation)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sources := []attributeGetter{plan}
+	if priorState != nil {
+		sources = append(sources, priorState)
+	}
+	sensitive := definitionSensitiveValues(ctx, sources...)
+	ctx = sensitive.maskLogs(ctx)
+	defer func() {
+		*diagnostics = sensitive.redactDiagnostics(*diagnostics)
+	}()
+
 	scope := make(map[string]string, len(data.Scope))
 	for key, elem := range data.Scope {
 		scope[key] = elem.ValueString()
@@ -181,44 +963,152 @@ func (r *ConfigurationResource) Upsert(ctx context.Context, diagnostics *diag.Di
 		},
 	}
 
-	var schema *models.RavelSchemaMeta
-	if data.Schema != nil {
-		schemaScope := make(map[string]string, len(data.Schema.Scope))
-		for key, elem := range data.Schema.Scope {
-			schemaScope[key] = elem.ValueString()
+	schema := data.Schema.meta()
+
+	definition, err := validators.DecodeJSONObject(data.Definition.ValueString(), true)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Invalid Ravel configuration definition",
+			err.Error(),
+		)
+		return
+	}
+
+	var createdConf *models.RavelConfig
+	if priorState == nil {
+		createdConf, err = r.client.CreateConfig(ctx, meta, schema, definition)
+		if isTimeout(ctx, err) {
+			addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration name: %s", meta.Name), timeout)
+			return
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Error Creating Ravel configuration",
+				err.Error(),
+			)
+			return
 		}
+	} else {
+		var prior *ConfigurationResourceModel
+		diagnostics.Append(priorState.Get(ctx, &prior)...)
 
-		schema = &models.RavelSchemaMeta{
-			RavelResourceMeta: models.RavelResourceMeta{
-				Name:  data.Schema.Name.ValueString(),
-				Scope: schemaScope,
-			},
-			Version: data.Schema.Version.ValueString(),
+		if diagnostics.HasError() {
+			return
 		}
-	}
 
-	var definition map[string]interface{}
-	if err := json.Unmarshal([]byte(data.Definition.ValueString()), &definition); err != nil {
-		return
+		id, expectedVersion := prior.Id, prior.Version
+
+		current, err := r.client.GetLatestConfig(ctx, id.ValueString())
+		if isTimeout(ctx, err) {
+			addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration ID: %s", id.ValueString()), timeout)
+			return
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Error Reading Ravel configuration",
+				fmt.Sprintf("Could not read latest version of Ravel configuration ID: %s. Error: %s ", id.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		meta.Labels = managedLabels(current.Meta.Labels, convertToStringMap(prior.Labels), labels)
+
+		// Terraform plans an update when only the formatting of the definition changed, the prior version is kept
+		if maps.Equal(meta.Labels, current.Meta.Labels) && onlyDefinitionFormatChanged(ctx, prior, data, definition) {
+			data.Id = prior.Id
+			data.Version = prior.Version
+			data.EffectiveDef = prior.EffectiveDef
+			data.DefinitionHash = prior.DefinitionHash
+			data.SecretReferences = prior.SecretReferences
+			data.SecretsHash = prior.SecretsHash
+
+			tflog.Trace(ctx, fmt.Sprintf("kept version: %d of configuration with id: %s, its definition is unchanged", prior.Version.ValueInt64(), prior.Id.ValueString()))
+
+			diagnostics.Append(state.Set(ctx, &data)...)
+			return
+		}
+		createdConf, err = r.client.UpdateConfig(ctx, id.ValueString(), expectedVersion.ValueInt64(), meta, schema, definition)
+		if isTimeout(ctx, err) {
+			addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration ID: %s", id.ValueString()), timeout)
+			return
+		}
+
+		var conflictErr *client.VersionConflictError
+		if errors.As(err, &conflictErr) {
+			diagnostics.AddError(
+				"Ravel configuration changed concurrently",
+				fmt.Sprintf("Ravel configuration ID: %s was updated outside of this Terraform run, its latest version is no longer %d. "+
+					"Refresh the state (for example with `terraform apply -refresh-only` and `track_latest = true`), review the changes and plan again. Error: %s",
+					id.ValueString(), expectedVersion.ValueInt64(), err.Error()),
+			)
+			return
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Error Updating Ravel configuration",
+				fmt.Sprintf("Could not update Ravel configuration ID: %s. Error: %s ", id.ValueString(), err.Error()),
+			)
+			return
+		}
 	}
 
-	createdConf, err := r.client.CreateConfig(ctx, meta, schema, definition)
+	data.Id = types.StringValue(createdConf.Id)
+	data.Version = types.Int64Value(createdConf.Meta.Version)
+
+	data.EffectiveDef, data.DefinitionHash, err = effectiveDefinition(createdConf.Spec.Def, convertToStringSlice(data.SensitivePaths))
 	if err != nil {
 		diagnostics.AddError(
-			"Error Creating Ravel configuration",
-			err.Error(),
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not encode definition of Ravel configuration ID: %s. Error: %s ", createdConf.Id, err.Error()),
 		)
 		return
 	}
 
-	data.Id = types.StringValue(createdConf.Id)
-	data.Version = types.Int64Value(createdConf.Meta.Version)
+	// Terraform requires the configured definition to be kept in the state, only the references Ravel stored in
+	// place of its plaintext values are recorded, and a keyed hash of those values to detect secret drift.
+	references := secretReferences(definition, createdConf.Spec.Def)
+	secretRefs, diags := types.MapValueFrom(ctx, types.StringType, references)
+	diagnostics.Append(diags...)
+	data.SecretReferences = secretRefs
+	data.SecretsHash = types.StringNull()
+	if len(references) > 0 {
+		data.SecretsHash = types.StringValue(secretsHash(createdConf.Id, references, definition))
+	}
 
 	tflog.Trace(ctx, fmt.Sprintf("upserted a configuration with id: %s and version: %d", createdConf.Id, createdConf.Meta.Version))
 
 	diagnostics.Append(state.Set(ctx, &data)...)
 }
 
+// onlyDefinitionFormatChanged reports whether the planned configuration publishes the same content as the prior one,
+// its definition only differing in whitespace or key ordering and its secrets unchanged. Labels are compared by the caller.
+func onlyDefinitionFormatChanged(ctx context.Context, prior *ConfigurationResourceModel, planned *ConfigurationResourceModel, definition map[string]any) bool {
+	if !prior.Name.Equal(planned.Name) ||
+		!reflect.DeepEqual(convertToStringMap(prior.Scope), convertToStringMap(planned.Scope)) ||
+		!reflect.DeepEqual(prior.Schema.meta(), planned.Schema.meta()) ||
+		!reflect.DeepEqual(convertToStringSlice(prior.SensitivePaths), convertToStringSlice(planned.SensitivePaths)) {
+		return false
+	}
+
+	equal, err := customtypes.SemanticallyEqualJSON(prior.Definition.ValueString(), planned.Definition.ValueString())
+	if err != nil || !equal {
+		return false
+	}
+
+	// Secrets changed outside of Terraform are published again
+	if prior.SecretsHash.IsNull() {
+		return true
+	}
+
+	references := map[string]string{}
+	if diags := prior.SecretReferences.ElementsAs(ctx, &references, false); diags.HasError() {
+		return false
+	}
+
+	return secretsHash(prior.Id.ValueString(), references, definition) == prior.SecretsHash.ValueString()
+}
+
 func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data *ConfigurationResourceModel
 
@@ -228,7 +1118,32 @@ func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	configuration, err := r.client.GetConfigVersion(ctx, data.Id.ValueString(), int(data.Version.ValueInt64()))
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sensitive := definitionSensitiveValues(ctx, req.State)
+	ctx = sensitive.maskLogs(ctx)
+	defer func() {
+		resp.Diagnostics = sensitive.redactDiagnostics(resp.Diagnostics)
+	}()
+
+	configuration, outOfBand, err := r.lookupConfiguration(ctx, data.Id.ValueString(), data.Version.ValueInt64(), data.TrackLatest.ValueBool())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("configuration with id: %s no longer exists, removing it from state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ravel configuration",
@@ -237,14 +1152,26 @@ func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	if outOfBand {
+		resp.Diagnostics.AddWarning(
+			"Ravel configuration changed outside of Terraform",
+			fmt.Sprintf("Ravel configuration ID: %s has version %d published outside of Terraform, last version written by Terraform is %d. "+
+				"The next apply will publish the configured definition as a new version.", data.Id.ValueString(), configuration.Meta.Version, data.Version.ValueInt64()),
+		)
+		data.Version = types.Int64Value(configuration.Meta.Version)
+	}
+
 	data.Name = types.StringValue(configuration.Meta.Name)
 
+	// Only the managed label keys are read, keys set outside of Terraform are left to their owner
 	var labels map[string]types.String
-	if configuration.Meta.Labels != nil {
-		labels = make(map[string]types.String, len(configuration.Meta.Labels))
+	if data.Labels != nil {
+		labels = make(map[string]types.String, len(data.Labels))
 
-		for key, val := range configuration.Meta.Labels {
-			labels[key] = types.StringValue(val)
+		for key := range data.Labels {
+			if val, ok := configuration.Meta.Labels[key]; ok {
+				labels[key] = types.StringValue(val)
+			}
 		}
 	}
 	data.Labels = labels
@@ -252,27 +1179,96 @@ func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadReque
 	data.Scope = copyAndConvertMap(configuration.Meta.Scope)
 
 	if configuration.Spec.ConfigurationFormat != nil {
-		schema := ConfigurationSchemaModel{}
+		data.Schema = configurationSchemaFrom(configuration.Spec.ConfigurationFormat)
+	}
+
+	definition := configuration.Spec.Def
 
-		schema.Name = types.StringValue(configuration.Spec.ConfigurationFormat.Name)
-		schema.Version = types.StringValue(configuration.Spec.ConfigurationFormat.Version)
-		schema.Scope = copyAndConvertMap(configuration.Spec.ConfigurationFormat.Scope)
+	data.EffectiveDef, data.DefinitionHash, err = effectiveDefinition(definition, convertToStringSlice(data.SensitivePaths))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration",
+			fmt.Sprintf("Could not encode definition of Ravel configuration ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var priorDefinition map[string]any
+	if !data.Definition.IsNull() {
+		_ = json.Unmarshal([]byte(data.Definition.ValueString()), &priorDefinition)
+	}
+
+	priorReferences := map[string]string{}
+	if !data.SecretReferences.IsNull() {
+		resp.Diagnostics.Append(data.SecretReferences.ElementsAs(ctx, &priorReferences, false)...)
+	}
+
+	references := secretReferences(priorDefinition, definition)
+	if len(priorReferences) > 0 && sameReferences(references, priorReferences) {
+		resolved, err := r.client.GetResolvedConfigVersion(ctx, data.Id.ValueString(), int(data.Version.ValueInt64()))
+		if isTimeout(ctx, err) {
+			addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", data.Id.ValueString()), timeout)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Ravel configuration secrets",
+				fmt.Sprintf("Could not resolve secrets of Ravel configuration ID: %s and version: %d. Error: %s ", data.Id.ValueString(), data.Version.ValueInt64(), err.Error()),
+			)
+			return
+		}
 
-		data.Schema = &schema
+		// Secret drift is only reported through the hash, the resolved plaintext is never written to the state
+		data.SecretsHash = types.StringValue(secretsHash(data.Id.ValueString(), references, resolved.Spec.Def))
 	}
 
-	var definition []byte
-	definition, err = json.Marshal(configuration.Spec.Def)
+	// The prior definition is kept when it only differs from the stored one by the values Ravel replaced with references
+	if len(references) > 0 && reflect.DeepEqual(withSecretReferences(priorDefinition, references), definition) {
+		definition = priorDefinition
+	}
+
+	secretRefs, diags := types.MapValueFrom(ctx, types.StringType, references)
+	resp.Diagnostics.Append(diags...)
+	data.SecretReferences = secretRefs
+
+	var definitionJSON []byte
+	definitionJSON, err = json.Marshal(definition)
 	if err != nil {
 		return
 	}
-	data.Definition = types.StringValue(string(definition))
+	data.Definition = customtypes.NewJSONValue(string(definitionJSON))
+	if data.SensitivePaths != nil {
+		if redacted, err := redactedDefinition(data.Definition.ValueString(), convertToStringSlice(data.SensitivePaths)); err == nil {
+			data.RedactedDef = redacted
+		}
+	}
+	data.DefinitionYAML = renderDefinition(&resp.Diagnostics, data.DefinitionYAML, definition)
+	data.DefinitionTOML = renderDefinition(&resp.Diagnostics, data.DefinitionTOML, definition)
+	data.DefinitionJSONC = renderDefinition(&resp.Diagnostics, data.DefinitionJSONC, definition)
+	data.Settings = renderSettings(ctx, &resp.Diagnostics, data.Settings, definition)
 
 	tflog.Trace(ctx, fmt.Sprintf("read configuration with id: %s and version: %d", data.Id, data.Version.ValueInt64()))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// lookupConfiguration returns the latest version of the configuration when tracking it, otherwise the version last
+// written by Terraform, and whether the returned version was published outside of Terraform.
+func (r *ConfigurationResource) lookupConfiguration(ctx context.Context, id string, version int64, trackLatest bool) (*models.RavelConfig, bool, error) {
+	var configuration *models.RavelConfig
+	var err error
+	if trackLatest {
+		configuration, err = r.client.GetLatestConfig(ctx, id)
+	} else {
+		configuration, err = r.client.GetConfigVersion(ctx, id, int(version))
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return configuration, configuration.Meta.Version != version, nil
+}
+
 func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data *ConfigurationResourceModel
 
@@ -282,7 +1278,25 @@ func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	err := r.client.DeleteConfig(ctx, data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Trace(ctx, fmt.Sprintf("configuration with id: %s was already deleted", data.Id.ValueString()))
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "delete", fmt.Sprintf("Ravel configuration ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Ravel configuration",
@@ -293,7 +1307,105 @@ func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *ConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importId, err := parseConfigurationImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ravel configuration import identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	// The `timeouts` block is not configured yet when importing, lookups are bounded by the default read timeout
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	id := importId.Id
+	var version int64
+
+	switch {
+	case importId.Name != "":
+		configs, err := r.client.FindConfigs(ctx, importId.Name, importId.Scope)
+		if isTimeout(ctx, err) {
+			addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration name: %s", importId.Name), defaultReadTimeout)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Looking up Ravel configuration",
+				fmt.Sprintf("Could not look up Ravel configuration name: %s and scope: %v. Error: %s ", importId.Name, importId.Scope, err.Error()),
+			)
+			return
+		}
+
+		if len(configs) != 1 {
+			resp.Diagnostics.AddError(
+				"Error Looking up Ravel configuration",
+				fmt.Sprintf("Expected exactly one Ravel configuration with name: %s and scope: %v, found %d. "+
+					"Add scope keys to the import identifier to narrow the lookup.", importId.Name, importId.Scope, len(configs)),
+			)
+			return
+		}
+
+		id = configs[0].Id
+		version = configs[0].Meta.Version
+	case importId.Version != nil:
+		version = *importId.Version
+	default:
+		latest, err := r.client.GetLatestConfig(ctx, id)
+		if isTimeout(ctx, err) {
+			addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", id), defaultReadTimeout)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Ravel configuration",
+				fmt.Sprintf("Could not read latest version of Ravel configuration ID: %s. Error: %s ", id, err.Error()),
+			)
+			return
+		}
+
+		version = latest.Meta.Version
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("importing configuration with id: %s and version: %d", id, version))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), version)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("track_latest"), false)...)
+}
+
+// managedLabels returns the current labels where the keys managed by Terraform, the prior and planned ones, are
+// replaced by the planned labels. Keys set outside of Terraform, such as by `ravel_configuration_labels`, are kept.
+func managedLabels(current models.Labels, prior map[string]string, planned map[string]string) models.Labels {
+	labels := maps.Clone(current)
+	if labels == nil {
+		labels = models.Labels{}
+	}
+
+	for key := range prior {
+		delete(labels, key)
+	}
+
+	for key, val := range planned {
+		labels[key] = val
+	}
+
+	return labels
+}
+
+func convertToStringMap(src map[string]types.String) map[string]string {
+	if src == nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(src))
+
+	for key, val := range src {
+		result[key] = val.ValueString()
+	}
+
+	return result
 }
 
 func copyAndConvertMap(src map[string]string) map[string]types.String {
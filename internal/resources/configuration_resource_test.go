@@ -97,17 +97,57 @@ var createAPIResponse = `{
     }
 }`
 
+var schemasAPIResponse = `[{
+    "id": "0f7c2f7e-1f0e-4c36-9a1c-3c3b9e0c8a11",
+    "meta": {
+        "name": "email",
+        "scope": {
+            "category": "fleetcommand-configuration-manager",
+            "source": "domino/release",
+            "type": "schema"
+        },
+        "version": "1.0.0"
+    },
+    "spec": {
+        "def": {
+            "type": "object",
+            "required": ["email_notifications"],
+            "properties": {
+                "email_notifications": {
+                    "type": "object",
+                    "properties": {
+                        "enabled": {"type": "boolean"},
+                        "port": {"type": "integer"}
+                    }
+                }
+            }
+        }
+    }
+}]`
+
 func mockAPIHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	if r.Method == http.MethodGet {
+	if r.Method == http.MethodGet && r.URL.Path == "/schemas" {
+		_, err := w.Write([]byte(schemasAPIResponse))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+	} else if r.Method == http.MethodGet && r.URL.Query().Get("secrets") == "resolve" {
 		_, err := w.Write([]byte(getAPIResponse))
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 
-	} else if r.Method == http.MethodPost {
+	} else if r.Method == http.MethodGet {
+		_, err := w.Write([]byte(createAPIResponse))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+	} else if r.Method == http.MethodPost || r.Method == http.MethodPut {
 		_, err := w.Write([]byte(createAPIResponse))
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,153 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var rollbackVersionResponse = `{
+    "id": "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+    "created_at": 1698258912,
+    "meta": {
+        "name": "smtp",
+        "version": 1
+    },
+    "spec": {
+        "def": {
+            "port": 465
+        }
+    }
+}`
+
+// newRollbackPlanRequest returns a plan request rolling the configuration back to the given version, the prior state
+// holding the given prior rollback version, or no state at all when prior is nil.
+func newRollbackPlanRequest(t *testing.T, r *ConfigurationResource, rollbackTo int64, prior *int64) (resource.ModifyPlanRequest, *resource.ModifyPlanResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.ModifyPlanRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: null},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: null},
+	}
+
+	diags := req.Plan.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(`{"port": 587}`))...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("rollback_to_version"), types.Int64Value(rollbackTo))...)
+
+	if prior != nil {
+		diags.Append(req.State.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")...)
+		diags.Append(req.State.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(`{"port": 587}`))...)
+		diags.Append(req.State.SetAttribute(ctx, path.Root("rollback_to_version"), types.Int64Value(*prior))...)
+	}
+
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	return req, &resource.ModifyPlanResponse{Plan: req.Plan}
+}
+
+func TestPlanRollback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/configurations/7eb918e0-49b6-4519-bb5a-850c42d8da04/versions/1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": "CONFIGURATION_NOT_FOUND", "message": "configuration version not found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(rollbackVersionResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	configured := &ConfigurationResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+	unconfigured := &ConfigurationResource{}
+	zero := int64(0)
+	one := int64(1)
+
+	testCases := map[string]struct {
+		resource   *ConfigurationResource
+		rollbackTo int64
+		prior      *int64
+		expected   customtypes.JSON
+		warning    bool
+		error      bool
+	}{
+		"rollback": {
+			resource:   configured,
+			rollbackTo: 1,
+			prior:      &zero,
+			expected:   customtypes.NewJSONValue(`{"port":465}`),
+			warning:    true,
+		},
+		"already rolled back": {
+			resource:   configured,
+			rollbackTo: 1,
+			prior:      &one,
+			expected:   customtypes.NewJSONValue(`{"port": 587}`),
+		},
+		"missing version": {
+			resource:   configured,
+			rollbackTo: 2,
+			prior:      &zero,
+			error:      true,
+		},
+		"not created yet": {
+			resource:   configured,
+			rollbackTo: 1,
+			error:      true,
+		},
+		"provider not configured": {
+			resource:   unconfigured,
+			rollbackTo: 1,
+			prior:      &zero,
+			expected:   customtypes.NewJSONUnknown(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			req, resp := newRollbackPlanRequest(t, testCase.resource, testCase.rollbackTo, testCase.prior)
+
+			testCase.resource.planRollback(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.error {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if testCase.error {
+				return
+			}
+
+			if (resp.Diagnostics.WarningsCount() > 0) != testCase.warning {
+				t.Errorf("unexpected warnings: %v", resp.Diagnostics.Warnings())
+			}
+
+			var definition customtypes.JSON
+			if diags := resp.Plan.GetAttribute(ctx, path.Root("definition"), &definition); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if !definition.Equal(testCase.expected) {
+				t.Errorf("expected definition %s, got %s", testCase.expected, definition)
+			}
+		})
+	}
+}
@@ -0,0 +1,627 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationSetResource{}
+var _ resource.ResourceWithModifyPlan = &ConfigurationSetResource{}
+
+func NewConfigurationSetResource() resource.Resource {
+	return &ConfigurationSetResource{}
+}
+
+type ConfigurationSetResource struct {
+	client *client.RavelClient
+}
+
+type ConfigurationSetEntryModel struct {
+	Id         types.String              `tfsdk:"id"`
+	Version    types.Int64               `tfsdk:"version"`
+	Name       types.String              `tfsdk:"name"`
+	Scope      map[string]types.String   `tfsdk:"scope"`
+	Labels     map[string]types.String   `tfsdk:"labels"`
+	Schema     *ConfigurationSchemaModel `tfsdk:"schema"`
+	Definition customtypes.JSON          `tfsdk:"definition"`
+}
+
+type ConfigurationSetResourceModel struct {
+	Id             types.String                          `tfsdk:"id"`
+	Configurations map[string]ConfigurationSetEntryModel `tfsdk:"configurations"`
+	Timeouts       timeouts.Value                        `tfsdk:"timeouts"`
+}
+
+func (r *ConfigurationSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_set"
+}
+
+func (r *ConfigurationSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Set of configurations reconciled together with batched requests, for fleets too large to manage with `for_each`. " +
+			"Plans report the changes of every configuration of the set. Configurations that fail to update keep their prior state " +
+			"and are retried by the next apply. A failure while creating the set taints it: the next apply deletes the configurations " +
+			"that were created and creates the whole set again",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configuration set identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configurations": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Configurations of the set by key. Changing the name or scope of a configuration replaces it",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Configuration identifier",
+						},
+						"version": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Configuration version",
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Configuration name",
+						},
+						"scope": schema.MapAttribute{
+							MarkdownDescription: "Configuration scope (Map<String, String>)",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"labels": schema.MapAttribute{
+							MarkdownDescription: "Configuration labels (Map<String, String>)",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"schema": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Schema name",
+								},
+								"version": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Schema version",
+								},
+								"scope": schema.MapAttribute{
+									MarkdownDescription: "Schema scope (Map<String, String>)",
+									ElementType:         types.StringType,
+									Optional:            true,
+								},
+							},
+						},
+						"definition": schema.StringAttribute{
+							CustomType: customtypes.JSONType{},
+							Required:   true,
+							Sensitive:  true,
+							MarkdownDescription: "Configuration definition (JSON). Whitespace and key ordering differences are ignored. " +
+								"Use `secret://` references for secret values to keep their plaintext out of the Terraform state",
+							Validators: []validator.String{
+								validators.SensitiveJSONObject(),
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans the ids and versions of the changed configurations and reports the changes of the set as a warning.
+func (r *ConfigurationSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plannedMap types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("configurations"), &plannedMap)...)
+
+	if resp.Diagnostics.HasError() || plannedMap.IsUnknown() {
+		return
+	}
+
+	// Configurations with unknown scopes or labels are planned on apply
+	var planned map[string]ConfigurationSetEntryModel
+	if diags := plannedMap.ElementsAs(ctx, &planned, false); diags.HasError() {
+		return
+	}
+
+	var prior map[string]ConfigurationSetEntryModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("configurations"), &prior)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for key, entry := range planned {
+		priorEntry, exists := prior[key]
+
+		switch {
+		case !exists || configurationSetIdentityChanged(priorEntry, entry):
+			entry.Id = types.StringUnknown()
+			entry.Version = types.Int64Unknown()
+		case configurationSetContentChanged(priorEntry, entry):
+			entry.Id = priorEntry.Id
+			entry.Version = types.Int64Unknown()
+		default:
+			entry.Id = priorEntry.Id
+			entry.Version = priorEntry.Version
+		}
+
+		planned[key] = entry
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("configurations"), planned)...)
+
+	if prior == nil {
+		return
+	}
+
+	if changes := describeConfigurationSetChanges(prior, planned); len(changes) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("configurations"),
+			"Ravel configuration set changes",
+			fmt.Sprintf("%d Ravel configurations will change (values of secret fields are masked):\n%s", len(changes), strings.Join(changes, "\n")),
+		)
+	}
+}
+
+func (r *ConfigurationSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ConfigurationSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, nil, &resp.State)
+}
+
+func (r *ConfigurationSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.Upsert(ctx, &resp.Diagnostics, &req.Plan, &req.State, &resp.State)
+}
+
+// Upsert reconciles the configurations of the set with the prior state, nil on create. Configurations that failed
+// are reported by key and the others are saved. On update they keep their prior state, so the next apply only
+// retries them. On create Terraform taints the saved set, so the next apply destroys and recreates all of it.
+func (r *ConfigurationSetResource) Upsert(ctx context.Context, diagnostics *diag.Diagnostics, plan *tfsdk.Plan, priorState *tfsdk.State, state *tfsdk.State) {
+	var data *ConfigurationSetResourceModel
+
+	diagnostics.Append(plan.Get(ctx, &data)...)
+
+	var prior map[string]ConfigurationSetEntryModel
+	operation := "create"
+	if priorState != nil {
+		operation = "update"
+		diagnostics.Append(priorState.GetAttribute(ctx, path.Root("configurations"), &prior)...)
+	}
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, operation)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if data.Id.IsUnknown() || data.Id.IsNull() {
+		data.Id = types.StringValue(uuid.NewString())
+	}
+
+	deletes, upserts, diags := configurationSetOperations(prior, data.Configurations)
+	diagnostics.Append(diags...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	reconciled := maps.Clone(prior)
+	if reconciled == nil {
+		reconciled = map[string]ConfigurationSetEntryModel{}
+	}
+
+	// Unchanged configurations take their planned value, which may only differ from the prior one in formatting
+	for key, entry := range data.Configurations {
+		if priorEntry, exists := prior[key]; exists && !configurationSetIdentityChanged(priorEntry, entry) && !configurationSetContentChanged(priorEntry, entry) {
+			entry.Id = priorEntry.Id
+			entry.Version = priorEntry.Version
+			reconciled[key] = entry
+		}
+	}
+
+	// Replaced configurations are only created once their prior configuration is deleted
+	failed := map[string]bool{}
+	deleted := r.apply(ctx, diagnostics, deletes, operation, timeout, func(op configurationSetOperation, result client.ConfigOperationResult) {
+		if result.Err != nil && !client.IsNotFound(result.Err) {
+			failed[op.Key] = true
+			addConfigurationSetError(diagnostics, op, result.Err)
+			return
+		}
+
+		delete(reconciled, op.Key)
+	})
+
+	if !deleted {
+		data.Configurations = reconciled
+		diagnostics.Append(state.Set(ctx, &data)...)
+		return
+	}
+
+	pending := make([]configurationSetOperation, 0, len(upserts))
+	for _, op := range upserts {
+		if !failed[op.Key] {
+			pending = append(pending, op)
+		}
+	}
+
+	r.apply(ctx, diagnostics, pending, operation, timeout, func(op configurationSetOperation, result client.ConfigOperationResult) {
+		if result.Err != nil {
+			addConfigurationSetError(diagnostics, op, result.Err)
+			return
+		}
+
+		entry := data.Configurations[op.Key]
+		entry.Id = types.StringValue(result.Config.Id)
+		entry.Version = types.Int64Value(result.Config.Meta.Version)
+		reconciled[op.Key] = entry
+	})
+
+	tflog.Trace(ctx, fmt.Sprintf("reconciled configuration set with id: %s, %d deletes and %d upserts", data.Id.ValueString(), len(deletes), len(pending)))
+
+	data.Configurations = reconciled
+
+	diagnostics.Append(state.Set(ctx, &data)...)
+}
+
+// apply sends the operations in batches and passes the result of every operation to handle. It returns false when
+// a batch could not be sent, only the results of the batches sent before it are handled then.
+func (r *ConfigurationSetResource) apply(ctx context.Context, diagnostics *diag.Diagnostics, ops []configurationSetOperation, operation string, timeout time.Duration, handle func(configurationSetOperation, client.ConfigOperationResult)) bool {
+	if len(ops) == 0 {
+		return true
+	}
+
+	batch := make([]models.RavelConfigOperation, 0, len(ops))
+	for _, op := range ops {
+		batch = append(batch, op.Op)
+	}
+
+	results, err := r.client.BatchConfigs(ctx, batch)
+
+	// Results of the batches applied before a failure are kept so they are saved to the state
+	for i, result := range results {
+		handle(ops[i], result)
+	}
+
+	if isTimeout(ctx, err) {
+		addTimeoutError(diagnostics, operation, fmt.Sprintf("Ravel configuration set of %d operations", len(ops)-len(results)), timeout)
+		return false
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error Applying Ravel configuration set",
+			fmt.Sprintf("Could not apply %d of %d configuration operations. Error: %s ", len(ops)-len(results), len(ops), err.Error()),
+		)
+		return false
+	}
+
+	return true
+}
+
+func addConfigurationSetError(diagnostics *diag.Diagnostics, op configurationSetOperation, err error) {
+	summary := "Error Applying Ravel configuration set"
+	detail := fmt.Sprintf("Could not %s Ravel configuration %q. Error: %s ", op.Op.Op, op.Key, err.Error())
+
+	var conflictErr *client.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		summary = "Ravel configuration changed concurrently"
+		detail = fmt.Sprintf("Ravel configuration %q ID: %s was updated outside of this Terraform run, its latest version is no longer %d. "+
+			"Refresh the state, review the changes and plan again. Error: %s", op.Key, op.Op.Id, conflictErr.ExpectedVersion, err.Error())
+	}
+
+	diagnostics.AddAttributeError(path.Root("configurations").AtMapKey(op.Key), summary, detail)
+}
+
+func (r *ConfigurationSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ConfigurationSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ids := make([]string, 0, len(data.Configurations))
+	for _, entry := range data.Configurations {
+		ids = append(ids, entry.Id.ValueString())
+	}
+	sort.Strings(ids)
+
+	configurations, err := r.client.GetLatestConfigs(ctx, ids)
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration set ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration set",
+			fmt.Sprintf("Could not read the %d configurations of Ravel configuration set ID: %s. Error: %s ", len(ids), data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	byId := make(map[string]models.RavelConfig, len(configurations))
+	for _, configuration := range configurations {
+		byId[configuration.Id] = configuration
+	}
+
+	var changed []string
+	for key, entry := range data.Configurations {
+		configuration, ok := byId[entry.Id.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("configuration %q with id: %s no longer exists, removing it from state", key, entry.Id.ValueString()))
+			delete(data.Configurations, key)
+			continue
+		}
+
+		// Definitions are only read back when a version was published outside of Terraform, since Ravel
+		// returns the secrets submitted in plaintext as references
+		if configuration.Meta.Version != entry.Version.ValueInt64() {
+			definition, err := json.Marshal(configuration.Spec.Def)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Ravel configuration set",
+					fmt.Sprintf("Could not encode definition of Ravel configuration %q ID: %s. Error: %s ", key, entry.Id.ValueString(), err.Error()),
+				)
+				return
+			}
+
+			changed = append(changed, key)
+			entry.Version = types.Int64Value(configuration.Meta.Version)
+			entry.Definition = customtypes.NewJSONValue(string(definition))
+		}
+
+		entry.Name = types.StringValue(configuration.Meta.Name)
+		entry.Scope = copyAndConvertMap(configuration.Meta.Scope)
+		entry.Labels = copyAndConvertMap(configuration.Meta.Labels)
+		entry.Schema = configurationSchemaFrom(configuration.Spec.ConfigurationFormat)
+
+		data.Configurations[key] = entry
+	}
+
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		resp.Diagnostics.AddWarning(
+			"Ravel configurations changed outside of Terraform",
+			fmt.Sprintf("Ravel configurations %s of configuration set ID: %s have versions published outside of Terraform. "+
+				"The next apply will publish the configured definitions as new versions.", strings.Join(changed, ", "), data.Id.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ConfigurationSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ConfigurationSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deletes, _, diags := configurationSetOperations(data.Configurations, nil)
+	resp.Diagnostics.Append(diags...)
+
+	r.apply(ctx, &resp.Diagnostics, deletes, "delete", timeout, func(op configurationSetOperation, result client.ConfigOperationResult) {
+		if result.Err != nil && !client.IsNotFound(result.Err) {
+			addConfigurationSetError(&resp.Diagnostics, op, result.Err)
+		}
+	})
+}
+
+// configurationSetOperation is a batch operation on the configuration with the given key in the set.
+type configurationSetOperation struct {
+	Key string
+	Op  models.RavelConfigOperation
+}
+
+// configurationSetOperations returns the deletes and the creates or updates reconciling prior with planned, sorted by key.
+// Configurations whose name or scope changed are deleted then created again.
+func configurationSetOperations(prior, planned map[string]ConfigurationSetEntryModel) ([]configurationSetOperation, []configurationSetOperation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var deletes, upserts []configurationSetOperation
+
+	for _, key := range sortedKeys(prior) {
+		entry, exists := planned[key]
+		if !exists || configurationSetIdentityChanged(prior[key], entry) {
+			deletes = append(deletes, configurationSetOperation{Key: key, Op: models.RavelConfigOperation{
+				Op: client.ConfigOpDelete,
+				Id: prior[key].Id.ValueString(),
+			}})
+		}
+	}
+
+	for _, key := range sortedKeys(planned) {
+		entry := planned[key]
+		priorEntry, exists := prior[key]
+
+		if exists && !configurationSetIdentityChanged(priorEntry, entry) && !configurationSetContentChanged(priorEntry, entry) {
+			continue
+		}
+
+		var definition map[string]any
+		if err := json.Unmarshal([]byte(entry.Definition.ValueString()), &definition); err != nil {
+			diags.AddAttributeError(
+				path.Root("configurations").AtMapKey(key).AtName("definition"),
+				"Invalid Ravel configuration definition",
+				err.Error(),
+			)
+			continue
+		}
+
+		config := &models.RavelConfig{
+			Meta: models.RavelConfigMeta{
+				RavelResourceMeta: models.RavelResourceMeta{
+					Name:   entry.Name.ValueString(),
+					Scope:  convertToStringMap(entry.Scope),
+					Labels: convertToStringMap(entry.Labels),
+				},
+			},
+			Spec: models.RavelConfigSpec{
+				ConfigurationFormat: entry.Schema.meta(),
+				Def:                 definition,
+			},
+		}
+
+		op := models.RavelConfigOperation{Op: client.ConfigOpCreate, Config: config}
+		if exists && !configurationSetIdentityChanged(priorEntry, entry) {
+			op = models.RavelConfigOperation{
+				Op:              client.ConfigOpUpdate,
+				Id:              priorEntry.Id.ValueString(),
+				ExpectedVersion: priorEntry.Version.ValueInt64Pointer(),
+				Config:          config,
+			}
+		}
+
+		upserts = append(upserts, configurationSetOperation{Key: key, Op: op})
+	}
+
+	return deletes, upserts, diags
+}
+
+// configurationSetIdentityChanged reports whether the name or scope of a configuration changed, which replaces it.
+func configurationSetIdentityChanged(prior, planned ConfigurationSetEntryModel) bool {
+	return !prior.Name.Equal(planned.Name) || !maps.Equal(convertToStringMap(prior.Scope), convertToStringMap(planned.Scope))
+}
+
+// configurationSetContentChanged reports whether publishing a new version of a configuration is needed.
+func configurationSetContentChanged(prior, planned ConfigurationSetEntryModel) bool {
+	if planned.Definition.IsUnknown() || !maps.Equal(convertToStringMap(prior.Labels), convertToStringMap(planned.Labels)) ||
+		!reflect.DeepEqual(prior.Schema.meta(), planned.Schema.meta()) {
+		return true
+	}
+
+	equal, err := customtypes.SemanticallyEqualJSON(prior.Definition.ValueString(), planned.Definition.ValueString())
+	return err != nil || !equal
+}
+
+// describeConfigurationSetChanges returns one description per changed configuration, sorted by key, with the
+// field-level changes of its definition.
+func describeConfigurationSetChanges(prior, planned map[string]ConfigurationSetEntryModel) []string {
+	var changes []string
+
+	keys := sortedKeys(prior)
+	for key := range planned {
+		if _, ok := prior[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		priorEntry, inPrior := prior[key]
+		plannedEntry, inPlanned := planned[key]
+
+		switch {
+		case !inPrior:
+			changes = append(changes, fmt.Sprintf("  + %s", key))
+		case !inPlanned:
+			changes = append(changes, fmt.Sprintf("  - %s", key))
+		case configurationSetIdentityChanged(priorEntry, plannedEntry):
+			changes = append(changes, fmt.Sprintf("-/+ %s (name or scope changed)", key))
+		case configurationSetContentChanged(priorEntry, plannedEntry):
+			description := fmt.Sprintf("  ~ %s", key)
+
+			var priorDefinition, plannedDefinition map[string]any
+			if json.Unmarshal([]byte(priorEntry.Definition.ValueString()), &priorDefinition) == nil &&
+				json.Unmarshal([]byte(plannedEntry.Definition.ValueString()), &plannedDefinition) == nil {
+				if definitionChanges := diffDefinitions(priorDefinition, plannedDefinition); len(definitionChanges) > 0 {
+					description += "\n  " + strings.ReplaceAll(renderDefinitionChanges(definitionChanges), "\n", "\n  ")
+				}
+			}
+
+			changes = append(changes, description)
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys(entries map[string]ConfigurationSetEntryModel) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,221 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func configurationSetEntry(id string, version int64, account string, definition string) ConfigurationSetEntryModel {
+	return ConfigurationSetEntryModel{
+		Id:         types.StringValue(id),
+		Version:    types.Int64Value(version),
+		Name:       types.StringValue("smtp"),
+		Scope:      map[string]types.String{"fleetcommand_account": types.StringValue(account)},
+		Definition: customtypes.NewJSONValue(definition),
+	}
+}
+
+func TestConfigurationSetOperations(t *testing.T) {
+	prior := map[string]ConfigurationSetEntryModel{
+		"acme":     configurationSetEntry("acme-id", 1, "acme", `{"port": 465}`),
+		"globex":   configurationSetEntry("globex-id", 2, "globex", `{"port": 465}`),
+		"initech":  configurationSetEntry("initech-id", 3, "initech", `{"port": 465}`),
+		"umbrella": configurationSetEntry("umbrella-id", 4, "umbrella", `{"port": 465}`),
+	}
+
+	planned := map[string]ConfigurationSetEntryModel{
+		// Formatting only, nothing to publish
+		"acme": configurationSetEntry("acme-id", 1, "acme", `{ "port" : 465 }`),
+		// Definition changed, new version
+		"globex": configurationSetEntry("globex-id", 2, "globex", `{"port": 587}`),
+		// Scope changed, replaced
+		"initech": configurationSetEntry("initech-id", 3, "initech-eu", `{"port": 465}`),
+		// Added
+		"hooli": configurationSetEntry("", 0, "hooli", `{"port": 465}`),
+	}
+
+	deletes, upserts, diags := configurationSetOperations(prior, planned)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var rendered []string
+	for _, op := range append(deletes, upserts...) {
+		rendered = append(rendered, op.Op.Op+" "+op.Key+" "+op.Op.Id)
+	}
+
+	expected := []string{
+		client.ConfigOpDelete + " initech initech-id",
+		client.ConfigOpDelete + " umbrella umbrella-id",
+		client.ConfigOpUpdate + " globex globex-id",
+		client.ConfigOpCreate + " hooli ",
+		client.ConfigOpCreate + " initech ",
+	}
+
+	if strings.Join(rendered, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected operations:\n%s", strings.Join(rendered, "\n"))
+	}
+
+	if upserts[0].Op.ExpectedVersion == nil || *upserts[0].Op.ExpectedVersion != 2 || upserts[0].Op.Config.Spec.Def["port"] != float64(587) {
+		t.Errorf("unexpected update: %+v", upserts[0].Op)
+	}
+
+	changes := describeConfigurationSetChanges(prior, planned)
+	expectedChanges := "  ~ globex\n    ~ port: 465 -> 587\n  + hooli\n-/+ initech (name or scope changed)\n  - umbrella"
+	if strings.Join(changes, "\n") != expectedChanges {
+		t.Errorf("unexpected changes:\n%s", strings.Join(changes, "\n"))
+	}
+}
+
+func TestConfigurationSetSavesPartialBatches(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Operations []models.RavelConfigOperation `json:"operations"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// The second batch is rejected as a whole
+		if requests == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": "INVALID_BATCH", "message": "invalid batch"}`))
+			return
+		}
+
+		results := make([]models.RavelConfigOperationResult, 0, len(body.Operations))
+		for _, op := range body.Operations {
+			created := *op.Config
+			created.Id = "id-" + created.Meta.Scope["fleetcommand_account"]
+			results = append(results, models.RavelConfigOperationResult{Status: http.StatusCreated, Config: &created})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	r := &ConfigurationSetResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	configurations := make(map[string]ConfigurationSetEntryModel, client.ConfigBatchSize+1)
+	for i := 0; i <= client.ConfigBatchSize; i++ {
+		account := fmt.Sprintf("account-%03d", i)
+		entry := configurationSetEntry("", 0, account, `{"port": 465}`)
+		entry.Id = types.StringUnknown()
+		entry.Version = types.Int64Unknown()
+		configurations[account] = entry
+	}
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: null}}
+	if diags := req.Plan.SetAttribute(ctx, path.Root("configurations"), configurations); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	r.Create(ctx, req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Could not apply 1 of 101 configuration operations") {
+		t.Fatalf("expected the failure of the second batch, got: %v", resp.Diagnostics)
+	}
+
+	var saved map[string]ConfigurationSetEntryModel
+	if diags := resp.State.GetAttribute(ctx, path.Root("configurations"), &saved); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if len(saved) != client.ConfigBatchSize {
+		t.Fatalf("expected the %d configurations of the first batch to be saved, got %d", client.ConfigBatchSize, len(saved))
+	}
+
+	if entry := saved["account-000"]; entry.Id.ValueString() != "id-account-000" {
+		t.Errorf("unexpected saved configuration: %+v", entry)
+	}
+}
+
+func TestConfigurationSetCreatePartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Operations []models.RavelConfigOperation `json:"operations"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		results := make([]models.RavelConfigOperationResult, 0, len(body.Operations))
+		for _, op := range body.Operations {
+			account := op.Config.Meta.Scope["fleetcommand_account"]
+			if account == "rejected" {
+				results = append(results, models.RavelConfigOperationResult{Status: http.StatusBadRequest, Code: "INVALID_CONFIG", Message: "invalid config"})
+				continue
+			}
+
+			created := *op.Config
+			created.Id = "id-" + account
+			results = append(results, models.RavelConfigOperationResult{Status: http.StatusCreated, Config: &created})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	r := &ConfigurationSetResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	configurations := map[string]ConfigurationSetEntryModel{}
+	for _, account := range []string{"accepted", "rejected"} {
+		entry := configurationSetEntry("", 0, account, `{"port": 465}`)
+		entry.Id = types.StringUnknown()
+		entry.Version = types.Int64Unknown()
+		configurations[account] = entry
+	}
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: null}}
+	if diags := req.Plan.SetAttribute(ctx, path.Root("configurations"), configurations); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	r.Create(ctx, req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), `Could not create Ravel configuration "rejected"`) {
+		t.Fatalf("expected the failure of the rejected configuration, got: %v", resp.Diagnostics)
+	}
+
+	// The state is saved with the error, so Terraform taints the set and the next apply recreates it
+	if resp.State.Raw.IsNull() {
+		t.Fatal("expected the created configurations to be saved")
+	}
+
+	var saved map[string]ConfigurationSetEntryModel
+	if diags := resp.State.GetAttribute(ctx, path.Root("configurations"), &saved); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if _, exists := saved["rejected"]; exists || saved["accepted"].Id.ValueString() != "id-accepted" {
+		t.Fatalf("expected only the accepted configuration to be saved, got: %+v", saved)
+	}
+}
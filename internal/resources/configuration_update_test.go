@@ -0,0 +1,206 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var updatedVersionResponse = `{
+    "id": "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+    "created_at": 1698258912,
+    "meta": {
+        "name": "smtp",
+        "version": 4
+    },
+    "spec": {
+        "def": {
+            "port": 587,
+            "server": "smtp.customer.org"
+        }
+    }
+}`
+
+func TestUpdateKeepsVersionOfReformattedDefinition(t *testing.T) {
+	tests := []struct {
+		name      string
+		planned   string
+		published bool
+		version   int64
+	}{
+		{
+			name:    "reformatted",
+			planned: `{"server":"smtp.customer.org","port":465}`,
+			version: 3,
+		},
+		{
+			name:      "changed",
+			planned:   `{"server":"smtp.customer.org","port":587}`,
+			published: true,
+			version:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				published = r.Method == http.MethodPut
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(updatedVersionResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			ctx := context.Background()
+			r := &ConfigurationResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: null},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: null},
+			}
+
+			prior := `{
+				"port": 465,
+				"server": "smtp.customer.org"
+			}`
+
+			diags := req.State.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")
+			diags.Append(req.State.SetAttribute(ctx, path.Root("name"), "smtp")...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("version"), types.Int64Value(3))...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(prior))...)
+			diags.Append(req.State.SetAttribute(ctx, path.Root("effective_definition"), customtypes.NewJSONValue(prior))...)
+
+			diags.Append(req.Plan.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")...)
+			diags.Append(req.Plan.SetAttribute(ctx, path.Root("name"), "smtp")...)
+			diags.Append(req.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+			diags.Append(req.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(tt.planned))...)
+			diags.Append(req.Plan.SetAttribute(ctx, path.Root("effective_definition"), customtypes.NewJSONUnknown())...)
+
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+			r.Update(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			if published != tt.published {
+				t.Errorf("expected a new version to be published: %t, got: %t", tt.published, published)
+			}
+
+			var version types.Int64
+			var definition customtypes.JSON
+			diags = resp.State.GetAttribute(ctx, path.Root("version"), &version)
+			diags.Append(resp.State.GetAttribute(ctx, path.Root("definition"), &definition)...)
+
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if version.ValueInt64() != tt.version {
+				t.Errorf("expected version %d, got: %d", tt.version, version.ValueInt64())
+			}
+
+			// Terraform requires the configured definition to be saved
+			if definition.ValueString() != tt.planned {
+				t.Errorf("expected the planned definition to be saved, got: %s", definition.ValueString())
+			}
+		})
+	}
+}
+
+func TestUpdateKeepsForeignLabels(t *testing.T) {
+	var published models.RavelConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&published)
+			_, _ = w.Write([]byte(updatedVersionResponse))
+			return
+		}
+
+		// minSchemaVersion is owned by ravel_configuration_labels
+		_, _ = w.Write([]byte(`{
+			"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04",
+			"meta": {
+				"name": "smtp",
+				"version": 3,
+				"labels": {"minSchemaVersion": "1.0.0", "owner": "platform", "team": "platform"}
+			},
+			"spec": {"def": {"port": 465, "server": "smtp.customer.org"}}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	r := &ConfigurationResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: null},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: null},
+	}
+
+	diags := req.State.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")
+	diags.Append(req.State.SetAttribute(ctx, path.Root("name"), "smtp")...)
+	diags.Append(req.State.SetAttribute(ctx, path.Root("version"), types.Int64Value(3))...)
+	diags.Append(req.State.SetAttribute(ctx, path.Root("labels"), map[string]string{"owner": "platform", "team": "platform"})...)
+	diags.Append(req.State.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(`{"port": 465, "server": "smtp.customer.org"}`))...)
+
+	// The owner label is no longer managed and the team label changes
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("id"), "7eb918e0-49b6-4519-bb5a-850c42d8da04")...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("name"), "smtp")...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("labels"), map[string]string{"team": "release"})...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("definition"), customtypes.NewJSONValue(`{"port": 465, "server": "smtp.customer.org"}`))...)
+	diags.Append(req.Plan.SetAttribute(ctx, path.Root("effective_definition"), customtypes.NewJSONUnknown())...)
+
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	r.Update(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	expected := models.Labels{"minSchemaVersion": "1.0.0", "team": "release"}
+	if !reflect.DeepEqual(published.Meta.Labels, expected) {
+		t.Errorf("expected labels %v to be published, got: %v", expected, published.Meta.Labels)
+	}
+
+	var labels map[string]string
+	if diags := resp.State.GetAttribute(ctx, path.Root("labels"), &labels); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if !reflect.DeepEqual(labels, map[string]string{"team": "release"}) {
+		t.Errorf("expected only the managed labels in state, got: %v", labels)
+	}
+}
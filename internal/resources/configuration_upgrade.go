@@ -0,0 +1,134 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configurationSchemaVersion is the version of the ravel_configuration schema, increment it along with
+// a new state upgrader whenever existing states cannot be read with the current schema.
+const configurationSchemaVersion = 1
+
+// configurationResourceModelV0 is the state layout of ravel_configuration before the schema was versioned.
+type configurationResourceModelV0 struct {
+	Id         types.String              `tfsdk:"id"`
+	Version    types.Int64               `tfsdk:"version"`
+	Name       types.String              `tfsdk:"name"`
+	Labels     map[string]types.String   `tfsdk:"labels"`
+	Scope      map[string]types.String   `tfsdk:"scope"`
+	Schema     *ConfigurationSchemaModel `tfsdk:"schema"`
+	Definition types.String              `tfsdk:"definition"`
+}
+
+func (r *ConfigurationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   configurationSchemaV0(),
+			StateUpgrader: upgradeConfigurationStateV0,
+		},
+	}
+}
+
+func configurationSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"version": schema.Int64Attribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"scope": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"schema": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"version": schema.StringAttribute{
+						Required: true,
+					},
+					"scope": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
+			"definition": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// upgradeConfigurationStateV0 keeps the attributes of the v0 layout, every attribute added since then is
+// left null, or set to its default, and is filled in by the next refresh.
+func upgradeConfigurationStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior configurationResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition := customtypes.NewJSONNull()
+	if !prior.Definition.IsNull() {
+		definition = customtypes.NewJSONValue(prior.Definition.ValueString())
+	}
+
+	upgraded := ConfigurationResourceModel{
+		Id:                prior.Id,
+		Version:           prior.Version,
+		Name:              prior.Name,
+		Labels:            prior.Labels,
+		Scope:             prior.Scope,
+		Schema:            prior.Schema,
+		Definition:        definition,
+		DefinitionYAML:    customtypes.NewDocumentNull(formats.YAML),
+		DefinitionTOML:    customtypes.NewDocumentNull(formats.TOML),
+		DefinitionJSONC:   customtypes.NewDocumentNull(formats.JSONC),
+		Settings:          types.DynamicNull(),
+		DefinitionFile:    types.StringNull(),
+		DefinitionSHA256:  types.StringNull(),
+		BaseConfiguration: types.StringNull(),
+		BasePinnedVersion: types.Int64Null(),
+		BaseVersion:       types.Int64Null(),
+		Overrides:         customtypes.NewJSONNull(),
+		EffectiveDef:      customtypes.NewJSONNull(),
+		DefinitionHash:    types.StringNull(),
+		RedactedDef:       customtypes.NewJSONNull(),
+		SecretReferences:  types.MapNull(types.StringType),
+		SecretsHash:       types.StringNull(),
+		TrackLatest:       types.BoolValue(false),
+		RollbackToVersion: types.Int64Null(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
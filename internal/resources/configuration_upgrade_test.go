@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpgradeConfigurationStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &ConfigurationResource{}
+
+	stored, err := os.ReadFile("testdata/configuration_state_v0.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("expected an upgrader from version 0")
+	}
+
+	priorState, err := tftypes.ValueFromJSONWithOpts(stored, upgrader.PriorSchema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	if schemaResp.Schema.Version != configurationSchemaVersion {
+		t.Fatalf("unexpected schema version: %d", schemaResp.Schema.Version)
+	}
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorState},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+
+	upgrader.StateUpgrader(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var upgraded ConfigurationResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if upgraded.Id.ValueString() != "7eb918e0-49b6-4519-bb5a-850c42d8da04" || upgraded.Version.ValueInt64() != 3 {
+		t.Errorf("unexpected identity: %s version %d", upgraded.Id, upgraded.Version.ValueInt64())
+	}
+
+	if upgraded.Labels["minSchemaVersion"].ValueString() != "001.000" || upgraded.Scope["type"].ValueString() != "configuration" {
+		t.Errorf("unexpected labels or scope: %v %v", upgraded.Labels, upgraded.Scope)
+	}
+
+	if upgraded.Schema == nil || upgraded.Schema.Name.ValueString() != "email" || upgraded.Schema.Scope["source"].ValueString() != "domino/release" {
+		t.Errorf("unexpected schema: %+v", upgraded.Schema)
+	}
+
+	if upgraded.Definition.ValueString() != `{"email_notifications":{"enabled":true,"port":465,"server":"email-smtp.us-east-1.amazonaws.com"}}` {
+		t.Errorf("unexpected definition: %s", upgraded.Definition.ValueString())
+	}
+
+	if upgraded.TrackLatest.ValueBool() || !upgraded.SecretReferences.IsNull() || !upgraded.DefinitionYAML.IsNull() || !upgraded.Settings.IsNull() {
+		t.Errorf("unexpected defaults: %+v", upgraded)
+	}
+}
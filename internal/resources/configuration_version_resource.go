@@ -0,0 +1,360 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigurationVersionResource{}
+var _ resource.ResourceWithImportState = &ConfigurationVersionResource{}
+
+// configurationVersionPublishAttempts bounds how many times publishing is retried when other versions are
+// published concurrently.
+const configurationVersionPublishAttempts = 3
+
+func NewConfigurationVersionResource() resource.Resource {
+	return &ConfigurationVersionResource{}
+}
+
+type ConfigurationVersionResource struct {
+	client *client.RavelClient
+}
+
+type ConfigurationVersionResourceModel struct {
+	Id              types.String              `tfsdk:"id"`
+	ConfigurationId types.String              `tfsdk:"configuration_id"`
+	Schema          *ConfigurationSchemaModel `tfsdk:"schema"`
+	Definition      customtypes.JSON          `tfsdk:"definition"`
+	Version         types.Int64               `tfsdk:"version"`
+	CreatedAt       types.String              `tfsdk:"created_at"`
+	Timeouts        timeouts.Value            `tfsdk:"timeouts"`
+}
+
+func (r *ConfigurationVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_configuration_version"
+}
+
+func (r *ConfigurationVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Immutable version of an existing configuration. Any change publishes a new version, " +
+			"destroying the resource keeps the version in the configuration history",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version identifier (`<configuration_id>@<version>`)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"configuration_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the configuration the version is published to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Schema name",
+					},
+					"version": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Schema version",
+					},
+					"scope": schema.MapAttribute{
+						MarkdownDescription: "Schema scope (Map<String, String>)",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"definition": schema.StringAttribute{
+				CustomType: customtypes.JSONType{},
+				Required:   true,
+				Sensitive:  true,
+				MarkdownDescription: "Configuration definition (JSON). Whitespace and key ordering differences are ignored. " +
+					"Use `secret://` references for secret values to keep their plaintext out of the Terraform state",
+				Validators: []validator.String{
+					validators.SensitiveJSONObject(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						definitionChanged,
+						"Publishes a new version unless the definition is only reformatted",
+						"Publishes a new version unless the definition is only reformatted",
+					),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Published configuration version",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Publication time of the version (RFC 3339)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ConfigurationVersionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ConfigurationVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ConfigurationVersionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(data.Definition.ValueString()), &definition); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Ravel configuration definition", err.Error())
+		return
+	}
+
+	configId := data.ConfigurationId.ValueString()
+
+	published, err := r.publish(ctx, configId, data.Schema.meta(), definition)
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "create", fmt.Sprintf("Ravel configuration ID: %s", configId), timeout)
+		return
+	}
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("configuration_id"),
+			"Ravel configuration not found",
+			fmt.Sprintf("Ravel configuration ID: %s does not exist, versions can only be published to existing configurations.", configId),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Publishing Ravel configuration version",
+			fmt.Sprintf("Could not publish a version of Ravel configuration ID: %s. Error: %s ", configId, err.Error()),
+		)
+		return
+	}
+
+	data.Version = types.Int64Value(published.Meta.Version)
+	data.Id = types.StringValue(configurationVersionId(configId, published.Meta.Version))
+	data.CreatedAt = configurationVersionCreatedAt(published)
+
+	tflog.Trace(ctx, fmt.Sprintf("published version: %d of configuration with id: %s", published.Meta.Version, configId))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// publish publishes definition as the new latest version of the configuration, keeping its name, scope and labels.
+// Versions published concurrently by others are not overwritten, publishing is retried on top of them.
+func (r *ConfigurationVersionResource) publish(ctx context.Context, configId string, format *models.RavelSchemaMeta, definition map[string]any) (*models.RavelConfig, error) {
+	return retryOnVersionConflict(ctx, configurationVersionPublishAttempts, func(attempt int) (*models.RavelConfig, error) {
+		latest, err := r.client.GetLatestConfig(ctx, configId)
+		if err != nil {
+			return nil, err
+		}
+
+		meta := models.RavelConfigMeta{RavelResourceMeta: latest.Meta.RavelResourceMeta}
+
+		return r.client.UpdateConfig(ctx, configId, latest.Meta.Version, meta, format, definition)
+	})
+}
+
+// retryOnVersionConflict calls publish until it succeeds, fails with another error than a version conflict,
+// or the attempts are exhausted, returning the last conflict.
+func retryOnVersionConflict(ctx context.Context, attempts int, publish func(attempt int) (*models.RavelConfig, error)) (*models.RavelConfig, error) {
+	var err error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var published *models.RavelConfig
+		published, err = publish(attempt)
+
+		var conflictErr *client.VersionConflictError
+		if !errors.As(err, &conflictErr) {
+			return published, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("configuration with id: %s changed concurrently, publishing attempt: %d", conflictErr.ConfigId, attempt))
+	}
+
+	return nil, err
+}
+
+// configurationVersionCreatedAt returns the RFC 3339 publication time of the version, null when Ravel does not return it.
+func configurationVersionCreatedAt(configuration *models.RavelConfig) types.String {
+	if configuration.CreatedAt == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.Unix(configuration.CreatedAt, 0).UTC().Format(time.RFC3339))
+}
+
+func (r *ConfigurationVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ConfigurationVersionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	configuration, err := r.client.GetConfigVersion(ctx, data.ConfigurationId.ValueString(), int(data.Version.ValueInt64()))
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("version: %d of configuration with id: %s no longer exists, removing it from state", data.Version.ValueInt64(), data.ConfigurationId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel configuration ID: %s", data.ConfigurationId.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel configuration version",
+			fmt.Sprintf("Could not read Ravel configuration ID: %s and version: %d. Error: %s ", data.ConfigurationId.ValueString(), data.Version.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	// Versions are immutable, the definition is only read on import since Ravel returns secrets as references
+	if data.Definition.IsNull() {
+		definition, err := json.Marshal(configuration.Spec.Def)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Ravel configuration version",
+				fmt.Sprintf("Could not encode definition of Ravel configuration ID: %s and version: %d. Error: %s ", data.ConfigurationId.ValueString(), data.Version.ValueInt64(), err.Error()),
+			)
+			return
+		}
+
+		data.Definition = customtypes.NewJSONValue(string(definition))
+		data.Schema = configurationSchemaFrom(configuration.Spec.ConfigurationFormat)
+	}
+
+	data.CreatedAt = configurationVersionCreatedAt(configuration)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// definitionChanged requires a replacement when the planned definition is not semantically equal to the published one,
+// reformatting the definition is applied in place.
+func definitionChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	equal, diags := customtypes.NewJSONValue(req.StateValue.ValueString()).StringSemanticEquals(ctx, customtypes.NewJSONValue(req.PlanValue.ValueString()))
+	resp.Diagnostics.Append(diags...)
+
+	resp.RequiresReplace = !equal
+}
+
+// Update only applies timeouts and definition formatting changes, any other change publishes a new version.
+func (r *ConfigurationVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *ConfigurationVersionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the version from the state, configuration history is never deleted.
+func (r *ConfigurationVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ConfigurationVersionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("version: %d of configuration with id: %s is kept in the configuration history", data.Version.ValueInt64(), data.ConfigurationId.ValueString()))
+}
+
+// ImportState imports a version from its `<configuration_id>@<version>` identifier.
+func (r *ConfigurationVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importId, err := parseConfigurationImportId(req.ID)
+	if err == nil && (importId.Id == "" || importId.Version == nil) {
+		err = fmt.Errorf("import identifier %q is invalid, expected `<configuration_id>@<version>`", req.ID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ravel configuration version import identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), configurationVersionId(importId.Id, *importId.Version))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("configuration_id"), importId.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), *importId.Version)...)
+}
+
+func configurationVersionId(configId string, version int64) string {
+	return configId + "@" + strconv.FormatInt(version, 10)
+}
@@ -0,0 +1,168 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefinitionChanged(t *testing.T) {
+	testCases := map[string]struct {
+		state    string
+		plan     string
+		expected bool
+	}{
+		"reformatted": {
+			state:    `{"port":465,"host":"smtp"}`,
+			plan:     "{\n  \"host\": \"smtp\",\n  \"port\": 465\n}",
+			expected: false,
+		},
+		"changed value": {
+			state:    `{"port":465}`,
+			plan:     `{"port":587}`,
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				StateValue: types.StringValue(testCase.state),
+				PlanValue:  types.StringValue(testCase.plan),
+			}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+
+			definitionChanged(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			if resp.RequiresReplace != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestRetryOnVersionConflict(t *testing.T) {
+	conflict := &client.VersionConflictError{ConfigId: "7eb918e0-49b6-4519-bb5a-850c42d8da04", ExpectedVersion: 3, Err: errors.New("conflict")}
+	failure := errors.New("unavailable")
+	published := &models.RavelConfig{Meta: models.RavelConfigMeta{Version: 4}}
+
+	testCases := map[string]struct {
+		results  []error
+		attempts int
+		expected error
+	}{
+		"published": {
+			results:  []error{nil},
+			attempts: 1,
+		},
+		"published after conflicts": {
+			results:  []error{conflict, conflict, nil},
+			attempts: 3,
+		},
+		"conflicts exhausted": {
+			results:  []error{conflict, conflict, conflict},
+			attempts: 3,
+			expected: conflict,
+		},
+		"other error": {
+			results:  []error{conflict, failure},
+			attempts: 2,
+			expected: failure,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			config, err := retryOnVersionConflict(context.Background(), 3, func(attempt int) (*models.RavelConfig, error) {
+				attempts++
+				if attempt != attempts {
+					t.Errorf("expected attempt %d, got %d", attempts, attempt)
+				}
+
+				if err := testCase.results[attempt-1]; err != nil {
+					return nil, err
+				}
+				return published, nil
+			})
+
+			if attempts != testCase.attempts {
+				t.Errorf("expected %d attempts, got %d", testCase.attempts, attempts)
+			}
+
+			if !errors.Is(err, testCase.expected) {
+				t.Fatalf("expected error %v, got %v", testCase.expected, err)
+			}
+
+			if testCase.expected == nil && config != published {
+				t.Errorf("unexpected published version: %+v", config)
+			}
+		})
+	}
+}
+
+func TestPublishRetriesOnConflict(t *testing.T) {
+	latest := 3
+	var ifMatches []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprintf(w, `{"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04", "meta": {"name": "smtp", "version": %d}, "spec": {"def": {}}}`, latest)
+		case http.MethodPut:
+			ifMatches = append(ifMatches, r.Header.Get("If-Match"))
+
+			// Another version is published concurrently with the first attempt
+			if len(ifMatches) == 1 {
+				latest++
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"code": "VERSION_CONFLICT", "message": "latest version is 4"}`))
+				return
+			}
+
+			latest++
+			_, _ = fmt.Fprintf(w, `{"id": "7eb918e0-49b6-4519-bb5a-850c42d8da04", "created_at": 1698258912, "meta": {"name": "smtp", "version": %d}, "spec": {"def": {"port": 465}}}`, latest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &ConfigurationVersionResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+
+	published, err := r.publish(context.Background(), "7eb918e0-49b6-4519-bb5a-850c42d8da04", nil, map[string]any{"port": 465})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ifMatches) != 2 || ifMatches[0] != `"3"` || ifMatches[1] != `"4"` {
+		t.Fatalf("unexpected If-Match headers: %v", ifMatches)
+	}
+
+	if published.Meta.Version != 5 {
+		t.Errorf("unexpected published version: %d", published.Meta.Version)
+	}
+
+	if createdAt := configurationVersionCreatedAt(published); createdAt.ValueString() != "2023-10-25T18:35:12Z" {
+		t.Errorf("unexpected creation time: %s", createdAt)
+	}
+}
+
+func TestConfigurationVersionCreatedAtMissing(t *testing.T) {
+	if createdAt := configurationVersionCreatedAt(&models.RavelConfig{}); !createdAt.IsNull() {
+		t.Fatalf("expected a null creation time, got: %s", createdAt)
+	}
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+)
+
+// definitionChange is a single field-level difference between two definitions.
+type definitionChange struct {
+	Path string
+	// Action is `+` for added fields, `-` for removed fields and `~` for changed fields.
+	Action string
+	Before any
+	After  any
+	Secret bool
+}
+
+// String renders the change, masking the values of secret-looking fields.
+func (c definitionChange) String() string {
+	switch c.Action {
+	case "+":
+		return fmt.Sprintf("+ %s = %s", c.Path, renderChangeValue(c.After, c.Secret))
+	case "-":
+		return fmt.Sprintf("- %s", c.Path)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, renderChangeValue(c.Before, c.Secret), renderChangeValue(c.After, c.Secret))
+	}
+}
+
+// diffDefinitions returns the field-level differences between prior and planned, sorted by path.
+// Objects are compared key by key and arrays index by index.
+func diffDefinitions(prior, planned map[string]any) []definitionChange {
+	var changes []definitionChange
+	diffValues(&changes, nil, prior, planned, false)
+
+	return changes
+}
+
+func diffValues(changes *[]definitionChange, segments []jsonpath.Segment, prior, planned any, secret bool) {
+	priorObject, priorIsObject := prior.(map[string]any)
+	plannedObject, plannedIsObject := planned.(map[string]any)
+
+	if priorIsObject && plannedIsObject {
+		keys := make([]string, 0, len(priorObject)+len(plannedObject))
+		for key := range priorObject {
+			keys = append(keys, key)
+		}
+		for key := range plannedObject {
+			if _, ok := priorObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keySegments := append(append([]jsonpath.Segment(nil), segments...), jsonpath.Segment{Key: key})
+			keySecret := secret || isSecretKey(key)
+
+			priorValue, inPrior := priorObject[key]
+			plannedValue, inPlanned := plannedObject[key]
+
+			switch {
+			case !inPrior:
+				*changes = append(*changes, definitionChange{Path: jsonpath.Join(keySegments), Action: "+", After: plannedValue, Secret: keySecret})
+			case !inPlanned:
+				*changes = append(*changes, definitionChange{Path: jsonpath.Join(keySegments), Action: "-", Before: priorValue, Secret: keySecret})
+			default:
+				diffValues(changes, keySegments, priorValue, plannedValue, keySecret)
+			}
+		}
+
+		return
+	}
+
+	priorArray, priorIsArray := prior.([]any)
+	plannedArray, plannedIsArray := planned.([]any)
+
+	if priorIsArray && plannedIsArray {
+		for index := 0; index < len(priorArray) || index < len(plannedArray); index++ {
+			indexSegments := append(append([]jsonpath.Segment(nil), segments...), jsonpath.Segment{Index: index, IsIndex: true})
+
+			switch {
+			case index >= len(priorArray):
+				*changes = append(*changes, definitionChange{Path: jsonpath.Join(indexSegments), Action: "+", After: plannedArray[index], Secret: secret})
+			case index >= len(plannedArray):
+				*changes = append(*changes, definitionChange{Path: jsonpath.Join(indexSegments), Action: "-", Before: priorArray[index], Secret: secret})
+			default:
+				diffValues(changes, indexSegments, priorArray[index], plannedArray[index], secret)
+			}
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(prior, planned) {
+		*changes = append(*changes, definitionChange{Path: jsonpath.Join(segments), Action: "~", Before: prior, After: planned, Secret: secret})
+	}
+}
+
+func renderChangeValue(value any, secret bool) string {
+	encoded, err := json.Marshal(maskSecrets(value, secret))
+	if err != nil {
+		return maskedValue
+	}
+
+	return string(encoded)
+}
+
+// renderDefinitionChanges renders one change per line.
+func renderDefinitionChanges(changes []definitionChange) string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, "  "+change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
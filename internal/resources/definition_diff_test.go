@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffDefinitions(t *testing.T) {
+	var prior, planned map[string]any
+
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"server": "smtp.customer.org",
+			"port": 465,
+			"legacy": {"enabled": true},
+			"recipients": ["ops@customer.org", "dev@customer.org"],
+			"authentication": {"username": "user", "password": "hunter2"}
+		}
+	}`), &prior); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"server": "smtp.customer.org",
+			"port": 587,
+			"recipients": ["ops@customer.org"],
+			"authentication": {"username": "user", "password": "correct horse"},
+			"api_key": "abc"
+		},
+		"proxy": {"host": "proxy", "token": "xyz"}
+	}`), &planned); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `  + email_notifications.api_key = "(sensitive)"
+  ~ email_notifications.authentication.password: "(sensitive)" -> "(sensitive)"
+  - email_notifications.legacy
+  ~ email_notifications.port: 465 -> 587
+  - email_notifications.recipients[1]
+  + proxy = {"host":"proxy","token":"(sensitive)"}`
+
+	if rendered := renderDefinitionChanges(diffDefinitions(prior, planned)); rendered != expected {
+		t.Errorf("unexpected changes:\n%s", rendered)
+	}
+
+	if changes := diffDefinitions(prior, prior); len(changes) != 0 {
+		t.Errorf("expected no changes, got: %v", changes)
+	}
+}
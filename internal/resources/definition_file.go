@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+)
+
+// definitionFileFormats maps the extensions of definition files to their format, any other extension is read as JSON.
+var definitionFileFormats = map[string]formats.Format{
+	".yaml":  formats.YAML,
+	".yml":   formats.YAML,
+	".toml":  formats.TOML,
+	".jsonc": formats.JSONC,
+}
+
+// readDefinitionFile reads the definition stored in the file at filename, returning it with the SHA-256 hash of the file.
+func readDefinitionFile(filename string) (definition map[string]any, sha256Hash string, err error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	format, ok := definitionFileFormats[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		format = formats.JSON
+	}
+
+	definition, err = formats.Decode(format, string(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid %s document: %w", format, err)
+	}
+
+	sum := sha256.Sum256(content)
+
+	return definition, hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadDefinitionFile(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"smtp.json": `{"email_notifications": {"server": "smtp.customer.org", "port": 465}}`,
+		"smtp.yaml": "email_notifications:\n  server: smtp.customer.org\n  port: 465\n",
+	}
+
+	expected := map[string]any{
+		"email_notifications": map[string]any{
+			"server": "smtp.customer.org",
+			"port":   float64(465),
+		},
+	}
+
+	hashes := map[string]bool{}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		definition, hash, err := readDefinitionFile(filename)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		if !reflect.DeepEqual(definition, expected) {
+			t.Errorf("%s: unexpected definition: %#v", name, definition)
+		}
+
+		if len(hash) != 64 {
+			t.Errorf("%s: unexpected hash: %s", name, hash)
+		}
+
+		hashes[hash] = true
+	}
+
+	if len(hashes) != len(files) {
+		t.Error("expected the hash to depend on the file content")
+	}
+}
+
+func TestReadDefinitionFileInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "smtp.json")
+	if err := os.WriteFile(filename, []byte(`["smtp.customer.org"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := readDefinitionFile(filename); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if _, _, err := readDefinitionFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
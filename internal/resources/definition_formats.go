@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// definitionFormatAttributes lists the alternative definition inputs, converted into `definition` at plan time.
+var definitionFormatAttributes = []string{"definition_yaml", "definition_toml", "definition_jsonc"}
+
+func definitionFormatAttribute(format formats.Format, formatName string) schema.StringAttribute {
+	return schema.StringAttribute{
+		CustomType: customtypes.DocumentType{Format: format},
+		Optional:   true,
+		Sensitive:  true,
+		MarkdownDescription: fmt.Sprintf("Configuration definition (%s), converted to JSON before being published. "+
+			"Comments and layout differences are ignored", formatName),
+		Validators: []validator.String{
+			validators.DocumentObject(format),
+		},
+	}
+}
+
+// renderDefinition renders definition in the format of prior, keeping prior when the content did not change.
+func renderDefinition(diagnostics *diag.Diagnostics, prior customtypes.Document, definition map[string]any) customtypes.Document {
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior
+	}
+
+	rendered, err := formats.Encode(prior.Format, definition)
+	if err != nil {
+		diagnostics.AddWarning(
+			"Ravel configuration definition cannot be rendered",
+			fmt.Sprintf("The definition stored by Ravel cannot be rendered as %s, changes made outside of Terraform will not be reported. Error: %s", prior.Format, err.Error()),
+		)
+		return prior
+	}
+
+	if equal, err := formats.SemanticallyEqual(prior.Format, prior.ValueString(), rendered); err == nil && equal {
+		return prior
+	}
+
+	return customtypes.NewDocumentValue(prior.Format, rendered)
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const configurationFormatResource = "configuration-format.json"
+
+// definitionViolation is a field of a definition rejected by its configuration format.
+type definitionViolation struct {
+	// Path is the dotted JSON path of the offending field, empty for the document itself.
+	Path    string
+	Message string
+}
+
+// validateDefinition validates definition against the JSON Schema document of a configuration format.
+func validateDefinition(schemaDef map[string]any, definition map[string]any) ([]definitionViolation, error) {
+	rawSchema, err := json.Marshal(schemaDef)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(configurationFormatResource, bytes.NewReader(rawSchema)); err != nil {
+		return nil, err
+	}
+
+	compiled, err := compiler.Compile(configurationFormatResource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiled.Validate(any(definition))
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	seen := map[definitionViolation]bool{}
+	var violations []definitionViolation
+	collectViolations(validationErr, any(definition), seen, &violations)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+
+	return violations, nil
+}
+
+// collectViolations keeps only the leaves of the validation error tree, they carry the most specific messages.
+func collectViolations(validationErr *jsonschema.ValidationError, definition any, seen map[definitionViolation]bool, violations *[]definitionViolation) {
+	if len(validationErr.Causes) > 0 {
+		for _, cause := range validationErr.Causes {
+			collectViolations(cause, definition, seen, violations)
+		}
+		return
+	}
+
+	violation := definitionViolation{
+		Path:    jsonpath.FromPointer(definition, validationErr.InstanceLocation),
+		Message: validationErr.Message,
+	}
+
+	if !seen[violation] {
+		seen[violation] = true
+		*violations = append(*violations, violation)
+	}
+}
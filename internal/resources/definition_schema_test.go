@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateDefinition(t *testing.T) {
+	var schemaDef, definition map[string]any
+
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["email_notifications"],
+		"properties": {
+			"email_notifications": {
+				"type": "object",
+				"required": ["server"],
+				"properties": {
+					"port": {"type": "integer"},
+					"server": {"type": "string"},
+					"recipients": {"type": "array", "items": {"type": "string"}}
+				}
+			}
+		}
+	}`), &schemaDef); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"port": "465",
+			"recipients": ["ops@customer.org", 42]
+		}
+	}`), &definition); err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := validateDefinition(schemaDef, definition)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var paths []string
+	for _, violation := range violations {
+		paths = append(paths, violation.Path)
+	}
+
+	expected := []string{"email_notifications", "email_notifications.port", "email_notifications.recipients[1]"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected violations at %v, got %+v", expected, violations)
+	}
+}
+
+func TestValidateDefinitionValid(t *testing.T) {
+	schemaDef := map[string]any{"type": "object"}
+	definition := map[string]any{"enabled": true}
+
+	violations, err := validateDefinition(schemaDef, definition)
+	if err != nil || len(violations) > 0 {
+		t.Fatalf("expected no violations, got %+v (error: %v)", violations, err)
+	}
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maskedValue replaces secret values in the definitions exposed as non sensitive attributes.
+const maskedValue = "(sensitive)"
+
+var secretKeyPattern = regexp.MustCompile(`(?i)(passw|secret|token|credential|private|api_?key|access_?key)`)
+
+// isSecretKey reports whether the values stored under key are likely secrets.
+func isSecretKey(key string) bool {
+	return secretKeyPattern.MatchString(key)
+}
+
+// effectiveDefinition returns the definition stored by Ravel with plaintext secrets and sensitivePaths masked,
+// along with the hash of its canonical JSON encoding.
+func effectiveDefinition(stored map[string]any, sensitivePaths []string) (customtypes.JSON, types.String, error) {
+	masked, err := json.Marshal(maskPaths(maskSecrets(stored, false).(map[string]any), sensitivePaths)) //nolint:forcetypeassert // copies keep their type
+	if err != nil {
+		return customtypes.NewJSONNull(), types.StringNull(), err
+	}
+
+	// Object keys are sorted and no whitespace is emitted, the encoding is canonical
+	canonical, err := json.Marshal(stored)
+	if err != nil {
+		return customtypes.NewJSONNull(), types.StringNull(), err
+	}
+
+	sum := sha256.Sum256(canonical)
+
+	return customtypes.NewJSONValue(string(masked)), types.StringValue(hex.EncodeToString(sum[:])), nil
+}
+
+// maskSecrets returns a copy of value where the scalars stored under secret-looking keys are masked,
+// unless they are secret references.
+func maskSecrets(value any, secret bool) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		masked := make(map[string]any, len(typed))
+		for key, elem := range typed {
+			masked[key] = maskSecrets(elem, secret || isSecretKey(key))
+		}
+		return masked
+	case []any:
+		masked := make([]any, len(typed))
+		for i, elem := range typed {
+			masked[i] = maskSecrets(elem, secret)
+		}
+		return masked
+	default:
+		if secret && value != nil && !isSecretReference(value) {
+			return maskedValue
+		}
+		return value
+	}
+}
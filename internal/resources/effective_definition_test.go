@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEffectiveDefinition(t *testing.T) {
+	var stored map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"server": "smtp.customer.org",
+			"port": 465,
+			"authentication": {"username": "user", "password": "123"},
+			"api_keys": ["abc", "secret://smtp/api-key"],
+			"client_secret": "secret://smtp/client-secret"
+		}
+	}`), &stored); err != nil {
+		t.Fatal(err)
+	}
+
+	effective, hash, err := effectiveDefinition(stored, []string{"email_notifications.server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"email_notifications":{"api_keys":["(sensitive)","secret://smtp/api-key"],` +
+		`"authentication":{"password":"(sensitive)","username":"user"},"client_secret":"secret://smtp/client-secret",` +
+		`"port":465,"server":"(sensitive)"}}`
+	if effective.ValueString() != expected {
+		t.Errorf("unexpected effective definition: %s", effective.ValueString())
+	}
+
+	var reordered map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"client_secret": "secret://smtp/client-secret",
+			"api_keys": ["abc", "secret://smtp/api-key"],
+			"authentication": {"password": "123", "username": "user"},
+			"port": 465,
+			"server": "smtp.customer.org"
+		}
+	}`), &reordered); err != nil {
+		t.Fatal(err)
+	}
+
+	_, reorderedHash, err := effectiveDefinition(reordered, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash.ValueString() != reorderedHash.ValueString() {
+		t.Errorf("expected a stable hash, got %s and %s", hash.ValueString(), reorderedHash.ValueString())
+	}
+
+	reordered["email_notifications"].(map[string]any)["port"] = float64(587)
+
+	_, changedHash, err := effectiveDefinition(reordered, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash.ValueString() == changedHash.ValueString() {
+		t.Error("expected the hash to change with the definition")
+	}
+}
@@ -0,0 +1,29 @@
+package resources
+
+// mergePatch applies the JSON merge patch (RFC 7396) patch to target, returning the patched object.
+// Nested objects are merged, null values remove the matching keys and any other value replaces the target one.
+// Neither target nor patch are modified.
+func mergePatch(target map[string]any, patch map[string]any) map[string]any {
+	merged := make(map[string]any, len(target))
+	for key, value := range target {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		patchObject, ok := value.(map[string]any)
+		if !ok {
+			merged[key] = value
+			continue
+		}
+
+		targetObject, _ := merged[key].(map[string]any)
+		merged[key] = mergePatch(targetObject, patchObject)
+	}
+
+	return merged
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	testCases := map[string]struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		"replace": {
+			target:   `{"server": "smtp.customer.org", "port": 465}`,
+			patch:    `{"port": 587}`,
+			expected: `{"server": "smtp.customer.org", "port": 587}`,
+		},
+		"nested": {
+			target:   `{"email_notifications": {"enabled": true, "authentication": {"username": "user", "password": "secret://smtp"}}}`,
+			patch:    `{"email_notifications": {"authentication": {"username": "account"}}}`,
+			expected: `{"email_notifications": {"enabled": true, "authentication": {"username": "account", "password": "secret://smtp"}}}`,
+		},
+		"remove": {
+			target:   `{"server": "smtp.customer.org", "proxy": {"host": "proxy"}}`,
+			patch:    `{"proxy": null, "missing": null}`,
+			expected: `{"server": "smtp.customer.org"}`,
+		},
+		"arrays are replaced": {
+			target:   `{"recipients": ["ops@customer.org", "dev@customer.org"]}`,
+			patch:    `{"recipients": ["support@customer.org"]}`,
+			expected: `{"recipients": ["support@customer.org"]}`,
+		},
+		"object replacing a scalar": {
+			target:   `{"proxy": "proxy:3128"}`,
+			patch:    `{"proxy": {"host": "proxy", "port": 3128, "user": null}}`,
+			expected: `{"proxy": {"host": "proxy", "port": 3128}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var target, patch, expected map[string]any
+			if err := json.Unmarshal([]byte(testCase.target), &target); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := json.Unmarshal([]byte(testCase.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := json.Unmarshal([]byte(testCase.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			targetCopy, _ := json.Marshal(target)
+
+			merged := mergePatch(target, patch)
+			if !reflect.DeepEqual(merged, expected) {
+				t.Errorf("unexpected merged object: %#v", merged)
+			}
+
+			if unchanged, _ := json.Marshal(target); string(unchanged) != string(targetCopy) {
+				t.Errorf("target was modified: %s", unchanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderData is shared by the provider with every resource it configures.
+type ProviderData struct {
+	Client *client.RavelClient
+	// DefaultLabels are merged into the labels of every configuration, the configuration values win.
+	DefaultLabels models.Labels
+	// DefaultScope is merged into the scope of every configuration, the configuration values win.
+	DefaultScope models.Scope
+}
+
+// providerDataFrom returns the data the provider configured resources with, nil while the provider is not configured.
+func providerDataFrom(req resource.ConfigureRequest, resp *resource.ConfigureResponse) *ProviderData {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resources.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return nil
+	}
+
+	return providerData
+}
+
+// mergeDefaults returns configured merged over defaults. The result is unknown while configured is unknown,
+// and null when neither holds a key.
+func mergeDefaults(defaults map[string]string, configured types.Map) (types.Map, diag.Diagnostics) {
+	if configured.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+
+	if len(defaults) == 0 {
+		return configured, nil
+	}
+
+	merged := make(map[string]attr.Value, len(defaults)+len(configured.Elements()))
+	for key, value := range defaults {
+		merged[key] = types.StringValue(value)
+	}
+
+	for key, value := range configured.Elements() {
+		merged[key] = value
+	}
+
+	return types.MapValue(types.StringType, merged)
+}
@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	defaults := map[string]string{"category": "fleetcommand-configuration-manager", "owner": "platform"}
+
+	configured := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"owner": types.StringValue("notifications"),
+		"type":  types.StringValue("configuration"),
+	})
+
+	merged, diags := mergeDefaults(defaults, configured)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	expected := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"category": types.StringValue("fleetcommand-configuration-manager"),
+		"owner":    types.StringValue("notifications"),
+		"type":     types.StringValue("configuration"),
+	})
+	if !merged.Equal(expected) {
+		t.Errorf("unexpected merged map: %s", merged)
+	}
+
+	if merged, _ := mergeDefaults(defaults, types.MapNull(types.StringType)); len(merged.Elements()) != 2 {
+		t.Errorf("expected the defaults when nothing is configured, got: %s", merged)
+	}
+
+	if merged, _ := mergeDefaults(nil, types.MapNull(types.StringType)); !merged.IsNull() {
+		t.Errorf("expected a null map without defaults, got: %s", merged)
+	}
+
+	if merged, _ := mergeDefaults(defaults, types.MapUnknown(types.StringType)); !merged.IsUnknown() {
+		t.Errorf("expected an unknown map, got: %s", merged)
+	}
+}
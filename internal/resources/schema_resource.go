@@ -0,0 +1,314 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SchemaResource{}
+var _ resource.ResourceWithImportState = &SchemaResource{}
+
+// semverPattern matches semantic versions (https://semver.org), such as 1.0.0 or 2.1.0-rc.1.
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+func NewSchemaResource() resource.Resource {
+	return &SchemaResource{}
+}
+
+type SchemaResource struct {
+	client *client.RavelClient
+}
+
+type SchemaResourceModel struct {
+	Id         types.String            `tfsdk:"id"`
+	Name       types.String            `tfsdk:"name"`
+	Version    types.String            `tfsdk:"version"`
+	Labels     map[string]types.String `tfsdk:"labels"`
+	Scope      map[string]types.String `tfsdk:"scope"`
+	Definition customtypes.JSON        `tfsdk:"definition"`
+	Timeouts   timeouts.Value          `tfsdk:"timeouts"`
+}
+
+func (r *SchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+func (r *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Configuration format (JSON Schema document) referenced by the `schema` of configurations",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Schema identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Schema name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Schema version (semantic version such as `1.0.0`). Changing it publishes a new schema, " +
+					"use `create_before_destroy` to keep the previous version while configurations move to the new one",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(semverPattern, "must be a semantic version such as 1.0.0"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Schema labels (Map<String, String>)",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"scope": schema.MapAttribute{
+				MarkdownDescription: "Schema scope (Map<String, String>)",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"definition": schema.StringAttribute{
+				CustomType:          customtypes.JSONType{},
+				Required:            true,
+				MarkdownDescription: "JSON Schema document validating the definition of configurations. Whitespace and key ordering differences are ignored",
+				Validators: []validator.String{
+					validators.JSONObject(),
+					validators.JSONSchema(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *SchemaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SchemaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(data.Definition.ValueString()), &definition); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Ravel schema definition", err.Error())
+		return
+	}
+
+	created, err := r.client.CreateSchema(ctx, data.meta(), definition)
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "create", fmt.Sprintf("Ravel schema name: %s", data.Name.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Ravel schema",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(created.Id)
+
+	tflog.Trace(ctx, fmt.Sprintf("created a schema with id: %s", created.Id))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SchemaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ravelSchema, err := r.client.GetSchema(ctx, data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("schema with id: %s no longer exists, removing it from state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel schema ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel schema",
+			fmt.Sprintf("Could not read Ravel schema ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	definition, err := json.Marshal(ravelSchema.Spec.Def)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel schema",
+			fmt.Sprintf("Could not encode definition of Ravel schema ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(ravelSchema.Meta.Name)
+	data.Version = types.StringValue(ravelSchema.Meta.Version)
+	data.Labels = copyAndConvertMap(ravelSchema.Meta.Labels)
+	data.Scope = copyAndConvertMap(ravelSchema.Meta.Scope)
+	data.Definition = customtypes.NewJSONValue(string(definition))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SchemaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(data.Definition.ValueString()), &definition); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Ravel schema definition", err.Error())
+		return
+	}
+
+	_, err := r.client.UpdateSchema(ctx, data.Id.ValueString(), data.meta(), definition)
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "update", fmt.Sprintf("Ravel schema ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ravel schema",
+			fmt.Sprintf("Could not update Ravel schema ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SchemaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := r.client.DeleteSchema(ctx, data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Trace(ctx, fmt.Sprintf("schema with id: %s was already deleted", data.Id.ValueString()))
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "delete", fmt.Sprintf("Ravel schema ID: %s", data.Id.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Ravel schema",
+			fmt.Sprintf("Could not delete Ravel schema ID: %s. Error: %s ", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *SchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (m *SchemaResourceModel) meta() models.RavelSchemaMeta {
+	return models.RavelSchemaMeta{
+		RavelResourceMeta: models.RavelResourceMeta{
+			Name:   m.Name.ValueString(),
+			Scope:  convertToStringMap(m.Scope),
+			Labels: convertToStringMap(m.Labels),
+		},
+		Version: m.Version.ValueString(),
+	}
+}
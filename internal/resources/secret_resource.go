@@ -0,0 +1,291 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SecretResource{}
+var _ resource.ResourceWithImportState = &SecretResource{}
+
+// secretStorePattern matches store names, which are the first segment of secret references.
+var secretStorePattern = regexp.MustCompile(`^[^/]+$`)
+
+func NewSecretResource() resource.Resource {
+	return &SecretResource{}
+}
+
+type SecretResource struct {
+	client *client.RavelClient
+}
+
+type SecretResourceModel struct {
+	Id        types.String            `tfsdk:"id"`
+	Store     types.String            `tfsdk:"store"`
+	Value     types.String            `tfsdk:"value"`
+	Labels    map[string]types.String `tfsdk:"labels"`
+	Reference types.String            `tfsdk:"reference"`
+	Timeouts  timeouts.Value          `tfsdk:"timeouts"`
+}
+
+func (r *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Secret value stored by Ravel, referenced from configuration definitions with its `secret://` reference",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Secret identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"store": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultSecretStore),
+				MarkdownDescription: "Secret store name, defaults to `" + defaultSecretStore + "`",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(secretStorePattern, "must be a non empty name without slashes"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				MarkdownDescription: "Secret value. Changing it rotates the secret in place, keeping its reference. " +
+					"Ravel never returns the value, so changes made outside of Terraform are not detected",
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Secret labels (Map<String, String>)",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"reference": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Secret reference (`secret://<store>/<id>`) to use in place of the value in configuration definitions",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData := providerDataFrom(req, resp)
+	if providerData == nil {
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sensitiveValues{data.Value.ValueString()}.maskLogs(ctx)
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "create")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	created, err := r.client.CreateSecret(ctx, data.meta(), data.Value.ValueString())
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "create", fmt.Sprintf("Ravel secret store: %s", data.Store.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Ravel secret",
+			sensitiveValues{data.Value.ValueString()}.redact(err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(created.Id)
+	data.Reference = types.StringValue(secretReference(data.Store.ValueString(), created.Id))
+
+	tflog.Trace(ctx, fmt.Sprintf("created a secret with reference: %s", data.Reference.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "read")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	secret, err := r.client.GetSecret(ctx, data.Store.ValueString(), data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("secret with reference: %s no longer exists, removing it from state", data.Reference.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "read", fmt.Sprintf("Ravel secret reference: %s", data.Reference.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ravel secret",
+			fmt.Sprintf("Could not read Ravel secret reference: %s. Error: %s ", data.Reference.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// The value is kept from the state since Ravel never returns it
+	data.Labels = copyAndConvertMap(secret.Meta.Labels)
+	data.Reference = types.StringValue(secretReference(data.Store.ValueString(), data.Id.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = sensitiveValues{data.Value.ValueString()}.maskLogs(ctx)
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "update")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := r.client.UpdateSecret(ctx, data.Id.ValueString(), data.meta(), data.Value.ValueString())
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "update", fmt.Sprintf("Ravel secret reference: %s", data.Reference.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ravel secret",
+			fmt.Sprintf("Could not update Ravel secret reference: %s. Error: %s ", data.Reference.ValueString(), sensitiveValues{data.Value.ValueString()}.redact(err.Error())),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := operationTimeout(ctx, data.Timeouts, "delete")
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := r.client.DeleteSecret(ctx, data.Store.ValueString(), data.Id.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Trace(ctx, fmt.Sprintf("secret with reference: %s was already deleted", data.Reference.ValueString()))
+		return
+	}
+	if isTimeout(ctx, err) {
+		addTimeoutError(&resp.Diagnostics, "delete", fmt.Sprintf("Ravel secret reference: %s", data.Reference.ValueString()), timeout)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Ravel secret",
+			fmt.Sprintf("Could not delete Ravel secret reference: %s. Error: %s ", data.Reference.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+// ImportState imports a secret from its `secret://<store>/<id>` reference, or `<store>/<id>`. The value cannot be
+// read back from Ravel, so the next apply rotates the secret to the configured value.
+func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	store, id, err := parseSecretReference(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ravel secret import identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("store"), store)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("reference"), secretReference(store, id))...)
+}
+
+func (m *SecretResourceModel) meta() models.RavelSecretMeta {
+	return models.RavelSecretMeta{
+		Store:  m.Store.ValueString(),
+		Labels: convertToStringMap(m.Labels),
+	}
+}
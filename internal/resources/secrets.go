@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+)
+
+const secretReferencePrefix = "secret://"
+
+// defaultSecretStore is the store Ravel uses for the plaintext secrets it rewrites into references.
+const defaultSecretStore = "default"
+
+func isSecretReference(value any) bool {
+	str, ok := value.(string)
+	return ok && strings.HasPrefix(str, secretReferencePrefix)
+}
+
+// secretReference returns the `secret://<store>/<id>` reference of a secret.
+func secretReference(store, id string) string {
+	return secretReferencePrefix + store + "/" + id
+}
+
+// parseSecretReference returns the store and id of a `secret://<store>/<id>` reference, the prefix is optional.
+func parseSecretReference(reference string) (string, string, error) {
+	store, id, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(reference), secretReferencePrefix), "/")
+	if !found || store == "" || id == "" || strings.Contains(id, "/") {
+		return "", "", fmt.Errorf("secret reference %q is invalid, expected `secret://<store>/<id>` or `<store>/<id>`", reference)
+	}
+
+	return store, id, nil
+}
+
+// secretReferences returns, by JSON path, the secret references Ravel stored in place of the submitted values.
+// Values submitted as references already are not reported.
+func secretReferences(submitted, stored map[string]any) map[string]string {
+	references := map[string]string{}
+
+	jsonpath.Walk(stored, func(path string, value any) {
+		if !isSecretReference(value) {
+			return
+		}
+
+		if submittedValue, ok := jsonpath.Get(submitted, path); ok && submittedValue == value {
+			return
+		}
+
+		references[path] = value.(string) //nolint:forcetypeassert // checked by isSecretReference
+	})
+
+	return references
+}
+
+// secretsHash returns an HMAC-SHA256 of the plaintext values found in values at the referenced paths, keyed with the
+// configuration identifier so equal secrets of different configurations do not share a hash.
+func secretsHash(key string, references map[string]string, values map[string]any) string {
+	paths := make([]string, 0, len(references))
+	for path := range references {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	hash := hmac.New(sha256.New, []byte(key))
+	for _, path := range paths {
+		value, _ := jsonpath.Get(values, path)
+		encoded, _ := json.Marshal(value)
+		fmt.Fprintf(hash, "%s=%s=%s\n", path, references[path], encoded)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// withSecretReferences returns a copy of definition with the values at the referenced paths replaced by their references.
+func withSecretReferences(definition map[string]any, references map[string]string) map[string]any {
+	masked, _ := deepCopy(definition).(map[string]any)
+	for path, reference := range references {
+		jsonpath.Set(masked, path, reference)
+	}
+
+	return masked
+}
+
+func sameReferences(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for path, ref := range a {
+		if b[path] != ref {
+			return false
+		}
+	}
+
+	return true
+}
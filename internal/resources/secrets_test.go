@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeDefinition(t *testing.T, definition string) map[string]any {
+	t.Helper()
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(definition), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
+
+func TestParseSecretReference(t *testing.T) {
+	for _, reference := range []string{"secret://smtp/0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21", "smtp/0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21"} {
+		store, id, err := parseSecretReference(reference)
+		if err != nil {
+			t.Fatalf("%s: %s", reference, err)
+		}
+
+		if store != "smtp" || id != "0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21" {
+			t.Errorf("%s: unexpected store %q and id %q", reference, store, id)
+		}
+
+		if secretReference(store, id) != "secret://smtp/0f3b8a43-5d4e-4f6a-9a55-7c1c2b6f9d21" {
+			t.Errorf("%s: unexpected reference %q", reference, secretReference(store, id))
+		}
+	}
+
+	for _, reference := range []string{"", "secret://", "0f3b8a43", "secret://smtp/", "secret:///0f3b8a43", "smtp/nested/0f3b8a43"} {
+		if _, _, err := parseSecretReference(reference); err == nil {
+			t.Errorf("expected an error for %q", reference)
+		}
+	}
+}
+
+func TestSecretReferences(t *testing.T) {
+	submitted := decodeDefinition(t, `{"auth":{"username":"user","password":"hunter2","token":"secret://smtp/b1"},"port":465}`)
+	stored := decodeDefinition(t, `{"auth":{"username":"user","password":"secret://default/a1","token":"secret://smtp/b1"},"port":465}`)
+
+	references := secretReferences(submitted, stored)
+
+	expected := map[string]string{"auth.password": "secret://default/a1"}
+	if !reflect.DeepEqual(references, expected) {
+		t.Fatalf("unexpected references: %v", references)
+	}
+
+	masked := withSecretReferences(submitted, references)
+	if !reflect.DeepEqual(masked, stored) {
+		t.Fatalf("unexpected definition with references: %v", masked)
+	}
+
+	if password := submitted["auth"].(map[string]any)["password"]; password != "hunter2" {
+		t.Fatalf("submitted definition was modified: %v", password)
+	}
+}
+
+func TestSecretsHash(t *testing.T) {
+	references := map[string]string{"auth.password": "secret://default/a1"}
+	values := decodeDefinition(t, `{"auth":{"password":"hunter2"},"port":465}`)
+
+	hash := secretsHash("config-1", references, values)
+
+	if hash != secretsHash("config-1", references, decodeDefinition(t, `{"port":587,"auth":{"password":"hunter2"}}`)) {
+		t.Error("expected the hash to only depend on the secret values")
+	}
+
+	if hash == secretsHash("config-1", references, decodeDefinition(t, `{"auth":{"password":"hunter3"}}`)) {
+		t.Error("expected the hash to change with the secret values")
+	}
+
+	if hash == secretsHash("config-2", references, values) {
+		t.Error("expected the hash to be keyed with the configuration identifier")
+	}
+
+	if hash == secretsHash("config-1", map[string]string{"auth.password": "secret://default/a2"}, values) {
+		t.Error("expected the hash to change with the secret references")
+	}
+}
+
+func TestSameReferences(t *testing.T) {
+	testCases := map[string]struct {
+		a, b     map[string]string
+		expected bool
+	}{
+		"empty": {
+			expected: true,
+		},
+		"equal": {
+			a:        map[string]string{"auth.password": "secret://default/a1"},
+			b:        map[string]string{"auth.password": "secret://default/a1"},
+			expected: true,
+		},
+		"rotated reference": {
+			a:        map[string]string{"auth.password": "secret://default/a1"},
+			b:        map[string]string{"auth.password": "secret://default/a2"},
+			expected: false,
+		},
+		"other path": {
+			a:        map[string]string{"auth.password": "secret://default/a1"},
+			b:        map[string]string{"auth.token": "secret://default/a1"},
+			expected: false,
+		},
+		"added path": {
+			a:        map[string]string{"auth.password": "secret://default/a1"},
+			b:        map[string]string{"auth.password": "secret://default/a1", "auth.token": "secret://default/b1"},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if sameReferences(testCase.a, testCase.b) != testCase.expected {
+				t.Fatalf("expected %t", testCase.expected)
+			}
+		})
+	}
+}
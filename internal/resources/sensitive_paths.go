@@ -0,0 +1,293 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maskPaths returns a copy of definition where the values found at paths are masked.
+func maskPaths(definition map[string]any, paths []string) map[string]any {
+	masked := deepCopy(definition).(map[string]any) //nolint:forcetypeassert // copies keep their type
+
+	for _, sensitivePath := range paths {
+		jsonpath.Set(masked, sensitivePath, maskedValue)
+	}
+
+	return masked
+}
+
+// redactedDefinition returns the JSON definition with the values found at paths masked.
+func redactedDefinition(definition string, paths []string) (customtypes.JSON, error) {
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(definition), &decoded); err != nil {
+		return customtypes.NewJSONNull(), err
+	}
+
+	redacted, err := json.Marshal(maskPaths(decoded, paths))
+	if err != nil {
+		return customtypes.NewJSONNull(), err
+	}
+
+	return customtypes.NewJSONValue(string(redacted)), nil
+}
+
+func deepCopy(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(typed))
+		for key, elem := range typed {
+			copied[key] = deepCopy(elem)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(typed))
+		for i, elem := range typed {
+			copied[i] = deepCopy(elem)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// sensitiveValues holds the values found at the sensitive paths of definitions, which must never
+// appear in logs or diagnostics.
+type sensitiveValues []string
+
+// attributeGetter is implemented by tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// definitionSensitiveValues returns the values found at the sensitive paths of the definition of every source.
+func definitionSensitiveValues(ctx context.Context, sources ...attributeGetter) sensitiveValues {
+	var paths []string
+	var definitions []map[string]any
+
+	for _, source := range sources {
+		var sourcePaths []types.String
+		var definition customtypes.JSON
+
+		// Sources missing either attribute have no sensitive value to contribute
+		if diags := source.GetAttribute(ctx, path.Root("sensitive_paths"), &sourcePaths); diags.HasError() {
+			continue
+		}
+		if diags := source.GetAttribute(ctx, path.Root("definition"), &definition); diags.HasError() {
+			continue
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(definition.ValueString()), &decoded); err != nil {
+			continue
+		}
+
+		paths = append(paths, convertToStringSlice(sourcePaths)...)
+		definitions = append(definitions, decoded)
+	}
+
+	return sensitiveValuesOf(paths, definitions...)
+}
+
+// sensitiveValuesOf returns the string values found at, or below, paths in the definitions. Numbers are left out,
+// they have many renderings and their digits appear in unrelated text, fields holding them are masked by path
+// with underSensitivePath instead. Secret references are not sensitive and are left out.
+func sensitiveValuesOf(paths []string, definitions ...map[string]any) sensitiveValues {
+	unique := map[string]bool{}
+
+	for _, definition := range definitions {
+		for _, sensitivePath := range paths {
+			value, ok := jsonpath.Get(definition, sensitivePath)
+			if !ok {
+				continue
+			}
+
+			jsonpath.Walk(value, func(_ string, leaf any) {
+				if typed, ok := leaf.(string); ok && typed != "" && !isSecretReference(typed) {
+					unique[typed] = true
+				}
+			})
+		}
+	}
+
+	values := make(sensitiveValues, 0, len(unique))
+	for value := range unique {
+		values = append(values, value)
+	}
+
+	// Longest values first so values containing other ones are fully redacted
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+
+	return values
+}
+
+// plannedSensitivePaths returns the known sensitive paths of plan, unknown paths cannot be masked yet and are left out.
+func plannedSensitivePaths(ctx context.Context, plan attributeGetter) []string {
+	var paths types.List
+	if diags := plan.GetAttribute(ctx, path.Root("sensitive_paths"), &paths); diags.HasError() {
+		return nil
+	}
+
+	var known []string
+	for _, elem := range paths.Elements() {
+		if value, ok := elem.(types.String); ok && !value.IsUnknown() {
+			known = append(known, value.ValueString())
+		}
+	}
+
+	return known
+}
+
+// underSensitivePath reports whether the field at fieldPath is one of paths, or is nested in one of them.
+func underSensitivePath(fieldPath string, paths []string) bool {
+	field, err := jsonpath.Parse(fieldPath)
+	if err != nil {
+		return false
+	}
+
+	for _, sensitivePath := range paths {
+		segments, err := jsonpath.Parse(sensitivePath)
+		if err != nil || len(segments) == 0 || len(segments) > len(field) {
+			continue
+		}
+
+		if slices.Equal(segments, field[:len(segments)]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nestedSensitivePaths returns the paths nested in the field at fieldPath, relative to it.
+func nestedSensitivePaths(fieldPath string, paths []string) []string {
+	field, err := jsonpath.Parse(fieldPath)
+	if err != nil {
+		return nil
+	}
+
+	var nested []string
+	for _, sensitivePath := range paths {
+		segments, err := jsonpath.Parse(sensitivePath)
+		if err != nil || len(segments) <= len(field) {
+			continue
+		}
+
+		if slices.Equal(segments[:len(field)], field) {
+			nested = append(nested, jsonpath.Join(segments[len(field):]))
+		}
+	}
+
+	return nested
+}
+
+// maskSensitiveChanges marks the changes of fields at, or below, paths as secret so their values are masked.
+// Changes of objects and arrays holding sensitive fields have those fields masked.
+func maskSensitiveChanges(changes []definitionChange, paths []string) []definitionChange {
+	for i := range changes {
+		if underSensitivePath(changes[i].Path, paths) {
+			changes[i].Secret = true
+			continue
+		}
+
+		if nested := nestedSensitivePaths(changes[i].Path, paths); len(nested) > 0 {
+			changes[i].Before = maskNestedPaths(changes[i].Before, nested)
+			changes[i].After = maskNestedPaths(changes[i].After, nested)
+		}
+	}
+
+	return changes
+}
+
+// maskNestedPaths returns a copy of value where the values found at paths are masked.
+func maskNestedPaths(value any, paths []string) any {
+	masked := deepCopy(value)
+	for _, nestedPath := range paths {
+		jsonpath.Set(masked, nestedPath, maskedValue)
+	}
+
+	return masked
+}
+
+// redact replaces the sensitive values found in message.
+func (v sensitiveValues) redact(message string) string {
+	for _, value := range v {
+		message = strings.ReplaceAll(message, value, maskedValue)
+	}
+
+	return message
+}
+
+// redactDiagnostics returns diagnostics with the sensitive values redacted from their summary and detail.
+func (v sensitiveValues) redactDiagnostics(diagnostics diag.Diagnostics) diag.Diagnostics {
+	if len(v) == 0 {
+		return diagnostics
+	}
+
+	redacted := make(diag.Diagnostics, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		summary, detail := v.redact(diagnostic.Summary()), v.redact(diagnostic.Detail())
+
+		var rewritten diag.Diagnostic
+		if diagnostic.Severity() == diag.SeverityError {
+			rewritten = diag.NewErrorDiagnostic(summary, detail)
+		} else {
+			rewritten = diag.NewWarningDiagnostic(summary, detail)
+		}
+
+		if withPath, ok := diagnostic.(diag.DiagnosticWithPath); ok {
+			rewritten = diag.WithPath(withPath.Path(), rewritten)
+		}
+
+		redacted = append(redacted, rewritten)
+	}
+
+	return redacted
+}
+
+// maskLogs returns a context whose provider logs have the sensitive values masked.
+func (v sensitiveValues) maskLogs(ctx context.Context) context.Context {
+	if len(v) == 0 {
+		return ctx
+	}
+
+	ctx = tflog.MaskMessageStrings(ctx, v...)
+	return tflog.MaskAllFieldValuesStrings(ctx, v...)
+}
+
+func hasUnknownElement(list types.List) bool {
+	for _, elem := range list.Elements() {
+		if elem.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func convertToStringSlice(values []types.String) []string {
+	if values == nil {
+		return nil
+	}
+
+	converted := make([]string, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, value.ValueString())
+	}
+
+	return converted
+}
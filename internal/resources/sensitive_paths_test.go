@@ -0,0 +1,123 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const sensitiveDefinition = `{
+	"email_notifications": {
+		"server": "smtp.customer.org",
+		"port": 465,
+		"authentication": {"username": "user", "password": "hunter2", "token": "secret://smtp/token"}
+	}
+}`
+
+func TestRedactedDefinition(t *testing.T) {
+	redacted, err := redactedDefinition(sensitiveDefinition, []string{"email_notifications.authentication.password", "email_notifications.missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"email_notifications":{"authentication":{"password":"(sensitive)","token":"secret://smtp/token","username":"user"},` +
+		`"port":465,"server":"smtp.customer.org"}}`
+	if redacted.ValueString() != expected {
+		t.Errorf("unexpected redacted definition: %s", redacted.ValueString())
+	}
+}
+
+func TestSensitiveValues(t *testing.T) {
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(sensitiveDefinition), &definition); err != nil {
+		t.Fatal(err)
+	}
+
+	values := sensitiveValuesOf([]string{"email_notifications.authentication", "email_notifications.port"}, definition)
+
+	// Numbers are masked by path, redacting their digits from free text would miss other renderings
+	if expected := (sensitiveValues{"hunter2", "user"}); !reflect.DeepEqual(values, expected) {
+		t.Fatalf("unexpected sensitive values: %#v", values)
+	}
+
+	if redacted := values.redact("invalid credentials user:hunter2 for port 465"); redacted != "invalid credentials (sensitive):(sensitive) for port 465" {
+		t.Errorf("unexpected redacted message: %s", redacted)
+	}
+
+	diagnostics := diag.Diagnostics{
+		diag.NewAttributeErrorDiagnostic(path.Root("definition"), "Invalid password hunter2", "Field password: hunter2 is too short"),
+		diag.NewWarningDiagnostic("Ravel configuration changed", "smtp.customer.org is unchanged"),
+	}
+
+	redacted := values.redactDiagnostics(diagnostics)
+
+	if len(redacted) != 2 || redacted[0].Summary() != "Invalid password (sensitive)" || redacted[0].Detail() != "Field password: (sensitive) is too short" {
+		t.Fatalf("unexpected redacted diagnostics: %#v", redacted)
+	}
+
+	if withPath, ok := redacted[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("definition")) {
+		t.Errorf("expected the attribute path to be kept: %#v", redacted[0])
+	}
+
+	if redacted[1].Severity() != diag.SeverityWarning || redacted[1].Detail() != "smtp.customer.org is unchanged" {
+		t.Errorf("unexpected redacted warning: %#v", redacted[1])
+	}
+}
+
+func TestUnderSensitivePath(t *testing.T) {
+	paths := []string{"email_notifications.port", "servers[1]", "invalid["}
+
+	testCases := map[string]bool{
+		"email_notifications.port":        true,
+		"email_notifications.port.nested": true,
+		"email_notifications.ports":       false,
+		"email_notifications":             false,
+		"servers[1].password":             true,
+		"servers[0].password":             false,
+		"":                                false,
+	}
+
+	for fieldPath, expected := range testCases {
+		if underSensitivePath(fieldPath, paths) != expected {
+			t.Errorf("%q: expected %t", fieldPath, expected)
+		}
+	}
+}
+
+func TestMaskSensitiveChanges(t *testing.T) {
+	var prior, planned map[string]any
+	if err := json.Unmarshal([]byte(`{"limits": {"quota": 1000, "burst": 10}}`), &prior); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"limits": {"quota": 1e4, "burst": 20}}`), &planned); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := maskSensitiveChanges(diffDefinitions(prior, planned), []string{"limits.quota"})
+
+	expected := "  ~ limits.burst: 10 -> 20\n  ~ limits.quota: \"(sensitive)\" -> \"(sensitive)\""
+	if rendered := renderDefinitionChanges(changes); rendered != expected {
+		t.Fatalf("unexpected changes:\n%s", rendered)
+	}
+}
+
+func TestMaskSensitiveChangesOfAddedParent(t *testing.T) {
+	var planned map[string]any
+	if err := json.Unmarshal([]byte(`{"smtp": {"host": "mail", "pin": 1234}}`), &planned); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := maskSensitiveChanges(diffDefinitions(map[string]any{}, planned), []string{"smtp.pin"})
+
+	rendered := renderDefinitionChanges(changes)
+	if strings.Contains(rendered, "1234") {
+		t.Fatalf("sensitive value leaked:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, `"pin":"(sensitive)"`) || !strings.Contains(rendered, `"host":"mail"`) {
+		t.Fatalf("unexpected changes:\n%s", rendered)
+	}
+}
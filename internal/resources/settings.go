@@ -0,0 +1,180 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/customtypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// settingsToDefinition converts the `settings` object into a definition.
+// known is false when the object still holds values unknown until apply.
+func settingsToDefinition(ctx context.Context, settings types.Dynamic) (definition map[string]any, known bool, err error) {
+	if settings.IsUnknown() || settings.IsUnderlyingValueUnknown() {
+		return nil, false, nil
+	}
+
+	value, err := settings.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !value.IsFullyKnown() {
+		return nil, false, nil
+	}
+
+	converted, err := terraformValueToJSON(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Round trip through JSON so numbers are represented like in any other definition
+	encoded, err := json.Marshal(converted)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := json.Unmarshal(encoded, &definition); err != nil || definition == nil {
+		return nil, false, fmt.Errorf("settings must be an object, got: %s", value.Type())
+	}
+
+	return definition, true, nil
+}
+
+// renderSettings renders definition as `settings`, keeping prior when the content did not change.
+func renderSettings(ctx context.Context, diagnostics *diag.Diagnostics, prior types.Dynamic, definition map[string]any) types.Dynamic {
+	if prior.IsNull() || prior.IsUnknown() || prior.IsUnderlyingValueNull() {
+		return prior
+	}
+
+	if priorDefinition, known, err := settingsToDefinition(ctx, prior); err == nil && known {
+		priorJSON, _ := json.Marshal(priorDefinition)
+		definitionJSON, _ := json.Marshal(definition)
+
+		if equal, err := customtypes.SemanticallyEqualJSON(string(priorJSON), string(definitionJSON)); err == nil && equal {
+			return prior
+		}
+	}
+
+	rendered, err := jsonToValue(definition)
+	if err != nil {
+		diagnostics.AddWarning(
+			"Ravel configuration definition cannot be rendered",
+			fmt.Sprintf("The definition stored by Ravel cannot be rendered as settings, changes made outside of Terraform will not be reported. Error: %s", err.Error()),
+		)
+		return prior
+	}
+
+	return types.DynamicValue(rendered)
+}
+
+func terraformValueToJSON(value tftypes.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	typ := value.Type()
+
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, err
+		}
+
+		converted := make([]any, len(elems))
+		for i, elem := range elems {
+			var err error
+			if converted[i], err = terraformValueToJSON(elem); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, err
+		}
+
+		converted := make(map[string]any, len(attrs))
+		for key, attr := range attrs {
+			var err error
+			if converted[key], err = terraformValueToJSON(attr); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("unsupported settings value type: %s", typ)
+	}
+}
+
+// jsonToValue converts a decoded JSON value into an object, tuple or primitive value.
+func jsonToValue(value any) (attr.Value, error) {
+	switch typed := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(typed), nil
+	case bool:
+		return types.BoolValue(typed), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(typed)), nil
+	case []any:
+		elemTypes := make([]attr.Type, len(typed))
+		elems := make([]attr.Value, len(typed))
+		for i, elem := range typed {
+			converted, err := jsonToValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes[i] = converted.Type(context.Background())
+			elems[i] = converted
+		}
+
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("cannot build tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(typed))
+		attrs := make(map[string]attr.Value, len(typed))
+		for key, elem := range typed {
+			converted, err := jsonToValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = converted.Type(context.Background())
+			attrs[key] = converted
+		}
+
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("cannot build object: %v", diags)
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value type: %T", value)
+	}
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSettingsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	var definition map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"email_notifications": {
+			"enabled": true,
+			"port": 465,
+			"ratio": 0.5,
+			"recipients": ["ops@customer.org", "dev@customer.org"],
+			"proxy": null
+		}
+	}`), &definition); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := jsonToValue(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, known, err := settingsToDefinition(ctx, types.DynamicValue(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !known {
+		t.Fatal("expected settings to be known")
+	}
+
+	if !reflect.DeepEqual(converted, definition) {
+		t.Fatalf("unexpected definition: %#v", converted)
+	}
+}
+
+func TestSettingsToDefinitionUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	settings, diags := types.ObjectValue(
+		map[string]attr.Type{"server": types.StringType},
+		map[string]attr.Value{"server": types.StringUnknown()},
+	)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	_, known, err := settingsToDefinition(ctx, types.DynamicValue(settings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if known {
+		t.Fatal("expected settings holding unknown values to be unknown")
+	}
+}
+
+func TestSettingsToDefinitionNotAnObject(t *testing.T) {
+	_, _, err := settingsToDefinition(context.Background(), types.DynamicValue(types.StringValue("smtp.customer.org")))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
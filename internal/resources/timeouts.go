@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// operationGerunds names every timed operation in diagnostics.
+var operationGerunds = map[string]string{
+	"create": "Creating",
+	"read":   "Reading",
+	"update": "Updating",
+	"delete": "Deleting",
+}
+
+// timeoutsBlock returns the `timeouts` block shared by every resource of the provider.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// operationTimeout returns the configured timeout of operation, falling back to its default.
+func operationTimeout(ctx context.Context, value timeouts.Value, operation string) (time.Duration, diag.Diagnostics) {
+	switch operation {
+	case "create":
+		return value.Create(ctx, defaultCreateTimeout)
+	case "read":
+		return value.Read(ctx, defaultReadTimeout)
+	case "update":
+		return value.Update(ctx, defaultUpdateTimeout)
+	default:
+		return value.Delete(ctx, defaultDeleteTimeout)
+	}
+}
+
+// planTimeout returns the read timeout configured in plan, which bounds the lookups made while planning.
+func planTimeout(ctx context.Context, plan tfsdk.Plan) (time.Duration, diag.Diagnostics) {
+	var value timeouts.Value
+	diags := plan.GetAttribute(ctx, path.Root("timeouts"), &value)
+	if diags.HasError() {
+		return defaultReadTimeout, diags
+	}
+
+	timeout, timeoutDiags := operationTimeout(ctx, value, "read")
+	diags.Append(timeoutDiags...)
+
+	return timeout, diags
+}
+
+// isTimeout reports whether err was caused by the deadline of ctx.
+func isTimeout(ctx context.Context, err error) bool {
+	return err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded))
+}
+
+// addTimeoutError reports that operation on the described object did not complete within timeout.
+func addTimeoutError(diagnostics *diag.Diagnostics, operation string, object string, timeout time.Duration) {
+	diagnostics.AddError(
+		fmt.Sprintf("Timeout %s %s", operationGerunds[operation], object),
+		fmt.Sprintf("%s %s did not complete within %s. Increase `%s` in the `timeouts` block to wait longer.",
+			operationGerunds[operation], object, timeout, operation),
+	)
+}
@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/client"
+	ravelhttp "github.com/cerebrotech/terraform-provider-ravel/internal/http"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestPlanTimeoutCancelsSlowLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := &ConfigurationResource{client: client.New(ravelhttp.New(server.URL, "test", "token"))}
+	zero := int64(0)
+
+	ctx := context.Background()
+	req, resp := newRollbackPlanRequest(t, r, 1, &zero)
+	if diags := resp.Plan.SetAttribute(ctx, path.Root("timeouts").AtName("read"), "50ms"); diags.HasError() {
+		t.Fatal(diags)
+	}
+	req.Plan = resp.Plan
+
+	timeout, diags := planTimeout(ctx, req.Plan)
+	if diags.HasError() || timeout != 50*time.Millisecond {
+		t.Fatalf("unexpected timeout %s: %v", timeout, diags)
+	}
+
+	start := time.Now()
+	r.planRollback(ctx, req, resp)
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("lookup was not cancelled, took %s", elapsed)
+	}
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.HasPrefix(resp.Diagnostics.Errors()[0].Summary(), "Timeout Reading") {
+		t.Fatalf("expected a timeout error, got: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/formats"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = documentObjectValidator{}
+
+// documentObjectValidator validates that a string holds a document of the given format whose top-level value is an object.
+type documentObjectValidator struct {
+	format formats.Format
+}
+
+// DocumentObject returns a validator which ensures that any configured string value is a document
+// of the given format holding an object.
+func DocumentObject(format formats.Format) validator.String {
+	return documentObjectValidator{format: format}
+}
+
+func (v documentObjectValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a %s document holding an object", v.format)
+}
+
+func (v documentObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v documentObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := formats.Decode(v.format, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Invalid %s Object", formatNames[v.format]),
+			err.Error(),
+		)
+	}
+}
+
+var formatNames = map[formats.Format]string{
+	formats.JSON:  "JSON",
+	formats.JSONC: "JSON With Comments",
+	formats.YAML:  "YAML",
+	formats.TOML:  "TOML",
+}
@@ -0,0 +1,151 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+const snippetRadius = 20
+
+var _ validator.String = jsonObjectValidator{}
+
+// jsonObjectValidator validates that a string holds a JSON document whose top-level value is an object.
+type jsonObjectValidator struct {
+	sensitive bool
+}
+
+// JSONObject returns a validator which ensures that any configured string value is a JSON object,
+// reporting syntax errors with their line, column and a snippet of the surrounding document.
+func JSONObject() validator.String {
+	return jsonObjectValidator{}
+}
+
+// SensitiveJSONObject returns a validator which ensures that any configured string value is a JSON object,
+// reporting syntax errors with their line and column only so the document never appears in diagnostics.
+func SensitiveJSONObject() validator.String {
+	return jsonObjectValidator{sensitive: true}
+}
+
+func (v jsonObjectValidator) Description(_ context.Context) string {
+	return "value must be a JSON document holding an object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := DecodeJSONObject(req.ConfigValue.ValueString(), v.sensitive); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			err.Error(),
+		)
+	}
+}
+
+// ValidateJSONObject returns a descriptive error when document is not a JSON object.
+func ValidateJSONObject(document string) error {
+	_, err := DecodeJSONObject(document, false)
+	return err
+}
+
+// DecodeJSONObject returns the object held by document, or a descriptive error when document is not a JSON object.
+// Errors of sensitive documents only locate syntax errors, without the surrounding snippet.
+func DecodeJSONObject(document string, sensitive bool) (map[string]any, error) {
+	var decoded any
+	if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column := position(document, syntaxErr.Offset)
+			if sensitive {
+				return nil, fmt.Errorf("invalid JSON at line %d, column %d", line, column)
+			}
+			return nil, fmt.Errorf("invalid JSON at line %d, column %d: %s\n\n%s", line, column, syntaxErr.Error(), snippet(document, syntaxErr.Offset))
+		}
+
+		return nil, fmt.Errorf("invalid JSON: %s", err.Error())
+	}
+
+	object, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the top-level JSON value must be an object, got %s", jsonKind(decoded))
+	}
+
+	return object, nil
+}
+
+// position returns the 1-based line and column of the offending character, offset being the number
+// of bytes read when the error was detected.
+func position(document string, offset int64) (int, int) {
+	if offset > int64(len(document)) {
+		offset = int64(len(document))
+	}
+
+	before := document[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := len(before) - strings.LastIndex(before, "\n") - 1
+	if column < 1 {
+		column = 1
+	}
+
+	return line, column
+}
+
+// snippet returns the characters surrounding offset on its line, with a marker under the offending character.
+func snippet(document string, offset int64) string {
+	if offset > int64(len(document)) {
+		offset = int64(len(document))
+	}
+
+	lineStart := strings.LastIndex(document[:offset], "\n") + 1
+	lineEnd := strings.IndexByte(document[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(document)
+	} else {
+		lineEnd += int(offset)
+	}
+
+	start := lineStart
+	if int(offset)-snippetRadius > start {
+		start = int(offset) - snippetRadius
+	}
+
+	end := lineEnd
+	if int(offset)+snippetRadius < end {
+		end = int(offset) + snippetRadius
+	}
+
+	marker := int(offset) - start - 1
+	if marker < 0 {
+		marker = 0
+	}
+
+	return fmt.Sprintf("  %s\n  %s^", strings.ReplaceAll(document[start:end], "\t", " "), strings.Repeat(" ", marker))
+}
+
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,95 @@
+package validators_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONObjectValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value         types.String
+		expectedError string
+	}{
+		"object": {
+			value: types.StringValue(`{"email_notifications": {"enabled": true}}`),
+		},
+		"null": {
+			value: types.StringNull(),
+		},
+		"unknown": {
+			value: types.StringUnknown(),
+		},
+		"syntax error": {
+			value:         types.StringValue("{\n  \"enabled\": true,\n  \"port\": 465,,\n}"),
+			expectedError: "invalid JSON at line 3, column 15",
+		},
+		"truncated": {
+			value:         types.StringValue(`{"enabled": true`),
+			expectedError: "invalid JSON at line 1, column 16",
+		},
+		"array": {
+			value:         types.StringValue(`[{"enabled": true}]`),
+			expectedError: "the top-level JSON value must be an object, got an array",
+		},
+		"json null": {
+			value:         types.StringValue(`null`),
+			expectedError: "the top-level JSON value must be an object, got null",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("definition"),
+				ConfigValue: testCase.value,
+			}
+			resp := &validator.StringResponse{}
+
+			validators.JSONObject().ValidateString(context.Background(), req, resp)
+
+			if testCase.expectedError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+			}
+
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, testCase.expectedError) {
+				t.Fatalf("expected error containing %q, got: %s", testCase.expectedError, detail)
+			}
+		})
+	}
+}
+
+func TestSensitiveJSONObjectValidator(t *testing.T) {
+	req := validator.StringRequest{
+		Path:        path.Root("definition"),
+		ConfigValue: types.StringValue("{\n  \"password\": \"hunter2\",,\n}"),
+	}
+	resp := &validator.StringResponse{}
+
+	validators.SensitiveJSONObject().ValidateString(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %v", resp.Diagnostics)
+	}
+
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if detail != "invalid JSON at line 2, column 25" {
+		t.Fatalf("unexpected error: %s", detail)
+	}
+
+	if strings.Contains(detail, "hunter2") {
+		t.Fatalf("error leaks the document: %s", detail)
+	}
+}
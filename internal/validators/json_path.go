@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"context"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/jsonpath"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = jsonPathValidator{}
+
+// jsonPathValidator validates that a string holds a dotted path addressing a value of a JSON document.
+type jsonPathValidator struct{}
+
+// JSONPath returns a validator which ensures that any configured string value is a dotted JSON path
+// such as `email_notifications.authentication.password` or `servers[0].host`.
+func JSONPath() validator.String {
+	return jsonPathValidator{}
+}
+
+func (v jsonPathValidator) Description(_ context.Context) string {
+	return "value must be a dotted JSON path"
+}
+
+func (v jsonPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonPathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := jsonpath.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Path",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const schemaDocumentResource = "schema.json"
+
+var _ validator.String = jsonSchemaValidator{}
+
+// jsonSchemaValidator validates that a string holds a JSON Schema document.
+type jsonSchemaValidator struct{}
+
+// JSONSchema returns a validator which ensures that any configured string value is a JSON object
+// compiling as a JSON Schema document.
+func JSONSchema() validator.String {
+	return jsonSchemaValidator{}
+}
+
+func (v jsonSchemaValidator) Description(_ context.Context) string {
+	return "value must be a JSON Schema document"
+}
+
+func (v jsonSchemaValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonSchemaValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// Syntax errors are reported by JSONObject
+	if ValidateJSONObject(req.ConfigValue.ValueString()) != nil {
+		return
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaDocumentResource, strings.NewReader(req.ConfigValue.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON Schema", err.Error())
+		return
+	}
+
+	if _, err := compiler.Compile(schemaDocumentResource); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON Schema", err.Error())
+	}
+}
@@ -0,0 +1,35 @@
+package validators_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cerebrotech/terraform-provider-ravel/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONSchema(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		invalid bool
+	}{
+		"valid":          {value: `{"type": "object", "properties": {"port": {"type": "integer", "minimum": 1}}}`},
+		"unknown type":   {value: `{"type": "port"}`, invalid: true},
+		"invalid syntax": {value: `{"type": `},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("definition"), ConfigValue: types.StringValue(testCase.value)}
+			resp := &validator.StringResponse{}
+
+			validators.JSONSchema().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.invalid {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}